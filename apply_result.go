@@ -8,32 +8,48 @@ type ApplyResult struct {
 	rawApplyResult C.Z3_apply_result
 }
 
+// newApplyResult wraps an apply result returned by Z3 and takes a reference to it,
+// which is released by Close or once the ApplyResult is garbage collected.
+func newApplyResult(rawCtx C.Z3_context, rawApplyResult C.Z3_apply_result) *ApplyResult {
+	checkError(rawCtx)
+	a := &ApplyResult{
+		rawCtx:         rawCtx,
+		rawApplyResult: rawApplyResult,
+	}
+	if rawApplyResult != nil {
+		C.Z3_apply_result_inc_ref(rawCtx, rawApplyResult)
+		track(rawCtx, a, func() {
+			C.Z3_apply_result_dec_ref(rawCtx, rawApplyResult)
+		})
+	}
+	return a
+}
+
 
 
 // String returns a human-friendly string version of the apply_result.
 func (t *ApplyResult) String() string {
-	return C.GoString(C.Z3_apply_result_to_string(t.rawCtx, t.rawApplyResult))
+	result := C.GoString(C.Z3_apply_result_to_string(t.rawCtx, t.rawApplyResult))
+	checkError(t.rawCtx)
+	return result
 }
 
 // Close decreases the reference count for this tactic. If nothing else
 // has manually increased the reference count, this will free the memory
 // associated with it.
 func (a *ApplyResult) Close() error {
-	C.Z3_apply_result_dec_ref(a.rawCtx, a.rawApplyResult)
+	untrack(a.rawCtx, a)
 	return nil
 }
 
 // Z3_apply_result_get_subgoal
 func (a *ApplyResult) GetSubgoal(i int) *Goal {
-	rawGoal := C.Z3_apply_result_get_subgoal(a.rawCtx, a.rawApplyResult, C.uint(i))
-	C.Z3_goal_inc_ref(a.rawCtx, rawGoal)
-	return &Goal{
-		rawCtx: a.rawCtx,
-		rawGoal: rawGoal,
-	}
+	return newGoal(a.rawCtx, C.Z3_apply_result_get_subgoal(a.rawCtx, a.rawApplyResult, C.uint(i)))
 }
 
 // Z3_apply_result_get_num_subgoals
 func (a *ApplyResult) GetNumSubgoals() int {
-	return int(C.Z3_apply_result_get_num_subgoals(a.rawCtx, a.rawApplyResult))
+	result := int(C.Z3_apply_result_get_num_subgoals(a.rawCtx, a.rawApplyResult))
+	checkError(a.rawCtx)
+	return result
 }
\ No newline at end of file
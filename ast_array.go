@@ -0,0 +1,160 @@
+package z3
+
+// #include "go-z3.h"
+import "C"
+
+// ConstArray creates an array over domain where every index maps to v.
+//
+// Maps to: Z3_mk_const_array
+func (c *Context) ConstArray(domain *Sort, v *AST) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_const_array(c.rawCtx, domain.rawSort, v.rawAST))
+}
+
+// ArrayDefault creates an AST node representing the default value of an
+// array, i.e. the value of all indices not explicitly stored to.
+//
+// Maps to: Z3_mk_array_default
+func (c *Context) ArrayDefault(array *AST) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_array_default(c.rawCtx, array.rawAST))
+}
+
+// Map creates an array whose value at each index is f applied to the
+// values of the given arrays at that index. f must take as many arguments
+// as there are arrays.
+//
+// Maps to: Z3_mk_map
+func (c *Context) Map(f *FuncDecl, arrays ...*AST) *AST {
+	raws := make([]C.Z3_ast, len(arrays))
+	for i, arg := range arrays {
+		raws[i] = arg.rawAST
+	}
+
+	var ptr *C.Z3_ast
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+	return newAST(c.rawCtx, C.Z3_mk_map(
+		c.rawCtx,
+		f.rawFuncDecl,
+		C.uint(len(raws)),
+		ptr))
+}
+
+// Lambda creates an array from a term over the given bound constants.
+// The resulting array maps each tuple of index values to body with the
+// bound constants replaced by those values.
+//
+// Maps to: Z3_mk_lambda_const
+func (c *Context) Lambda(bound []*AST, body *AST) *AST {
+	raws := make([]C.Z3_app, len(bound))
+	for i, b := range bound {
+		raws[i] = C.Z3_to_app(c.rawCtx, b.rawAST)
+	}
+
+	var ptr *C.Z3_app
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+	return newAST(c.rawCtx, C.Z3_mk_lambda_const(
+		c.rawCtx,
+		C.uint(len(raws)),
+		ptr,
+		body.rawAST))
+}
+
+// Select creates an AST node representing the value of the array a at
+// index i.
+//
+// Maps to: Z3_mk_select
+func (a *AST) Select(i *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_select(a.rawCtx, a.rawAST, i.rawAST))
+}
+
+// SelectN creates an AST node representing the value of the
+// multi-dimensional array a at the given indices.
+//
+// Maps to: Z3_mk_select_n
+func (a *AST) SelectN(idxs ...*AST) *AST {
+	raws := make([]C.Z3_ast, len(idxs))
+	for i, idx := range idxs {
+		raws[i] = idx.rawAST
+	}
+
+	var ptr *C.Z3_ast
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+	return newAST(a.rawCtx, C.Z3_mk_select_n(
+		a.rawCtx,
+		a.rawAST,
+		C.uint(len(raws)),
+		ptr))
+}
+
+// Store creates an AST node representing the array a updated so that
+// index i maps to v.
+//
+// Maps to: Z3_mk_store
+func (a *AST) Store(i, v *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_store(a.rawCtx, a.rawAST, i.rawAST, v.rawAST))
+}
+
+// StoreN creates an AST node representing the multi-dimensional array a
+// updated so that the given indices map to v.
+//
+// Maps to: Z3_mk_store_n
+func (a *AST) StoreN(idxs []*AST, v *AST) *AST {
+	raws := make([]C.Z3_ast, len(idxs))
+	for i, idx := range idxs {
+		raws[i] = idx.rawAST
+	}
+
+	var ptr *C.Z3_ast
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+	return newAST(a.rawCtx, C.Z3_mk_store_n(
+		a.rawCtx,
+		a.rawAST,
+		C.uint(len(raws)),
+		ptr,
+		v.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Array Values
+//-------------------------------------------------------------------
+
+// ArrayEntry is a single explicitly assigned index of an ArrayValue.
+// Index has one element per array dimension.
+type ArrayEntry struct {
+	Index []*AST
+	Value *AST
+}
+
+// ArrayValue is the concrete value of an array in a model: a finite list
+// of explicit entries, with every other index mapping to Default.
+type ArrayValue struct {
+	Entries []ArrayEntry
+	Default *AST
+}
+
+// Map returns the entries of the array keyed by the String value of
+// their index. Indices of multi-dimensional arrays are joined by a space.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (v *ArrayValue) Map() map[string]*AST {
+	result := make(map[string]*AST, len(v.Entries))
+	for _, e := range v.Entries {
+		key := ""
+		for i, idx := range e.Index {
+			if i > 0 {
+				key += " "
+			}
+			key += idx.String()
+		}
+		result[key] = e.Value
+	}
+	return result
+}
@@ -0,0 +1,197 @@
+package z3
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestASTSelectStore(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	a := ctx.Const(ctx.Symbol("a"), ctx.ArraySort(intTyp, intTyp))
+	i := ctx.Const(ctx.Symbol("i"), intTyp)
+
+	raw := a.Store(i, ctx.Int(1, intTyp)).Select(i)
+
+	actual := raw.String()
+	if actual != "(select (store a i 1) i)" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	if v := raw.Simplify().String(); v != "1" {
+		t.Fatalf("bad:\n%s", v)
+	}
+}
+
+func TestArraySortN(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	typ := ctx.ArraySortN([]*Sort{intTyp, ctx.BoolSort()}, intTyp)
+	a := ctx.Const(ctx.Symbol("a"), typ)
+
+	raw := a.StoreN([]*AST{ctx.Int(1, intTyp), ctx.True()}, ctx.Int(7, intTyp)).
+		SelectN(ctx.Int(1, intTyp), ctx.True())
+
+	if v := raw.Simplify().String(); v != "7" {
+		t.Fatalf("bad:\n%s", v)
+	}
+}
+
+func TestConstArray(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	a := ctx.ConstArray(intTyp, ctx.Int(5, intTyp))
+
+	if v := a.Select(ctx.Int(42, intTyp)).Simplify().String(); v != "5" {
+		t.Fatalf("bad:\n%s", v)
+	}
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	// The default of a constant array is its constant
+	s.Assert(ctx.ArrayDefault(a).Eq(ctx.Int(5, intTyp)).Not())
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+}
+
+func TestLambda(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+
+	// lambda x. x + 1
+	inc := ctx.Lambda([]*AST{x}, x.Add(ctx.Int(1, intTyp)))
+
+	if v := inc.Select(ctx.Int(41, intTyp)).Simplify().String(); v != "42" {
+		t.Fatalf("bad:\n%s", v)
+	}
+}
+
+func TestModelArrayValue(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	a := ctx.Const(ctx.Symbol("a"), ctx.ArraySort(intTyp, intTyp))
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	s.Assert(a.Select(ctx.Int(1, intTyp)).Eq(ctx.Int(10, intTyp)))
+	s.Assert(a.Select(ctx.Int(2, intTyp)).Eq(ctx.Int(20, intTyp)))
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+
+	v, err := m.ArrayValue(a)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v.Default == nil {
+		t.Fatal("should have a default value")
+	}
+
+	// Z3 is free to fold either index into the default value
+	entries := v.Map()
+	lookup := func(idx string) string {
+		if e, ok := entries[idx]; ok {
+			return e.String()
+		}
+		return v.Default.String()
+	}
+	if e := lookup("1"); e != "10" {
+		t.Fatalf("bad: %s", e)
+	}
+	if e := lookup("2"); e != "20" {
+		t.Fatalf("bad: %s", e)
+	}
+}
+
+func TestModelArrayValueStore(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	a := ctx.Const(ctx.Symbol("a"), ctx.ArraySort(intTyp, intTyp))
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	zero := ctx.ConstArray(intTyp, ctx.Int(0, intTyp))
+	s.Assert(a.Eq(zero.Store(ctx.Int(3, intTyp), ctx.Int(4, intTyp)).
+		Store(ctx.Int(3, intTyp), ctx.Int(9, intTyp))))
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+
+	v, err := m.ArrayValue(a)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v.Default == nil || v.Default.String() != "0" {
+		t.Fatalf("bad: %v", v.Default)
+	}
+	if e := v.Map()["3"]; e == nil || e.String() != "9" {
+		t.Fatalf("bad: %v", v.Map())
+	}
+}
+
+func TestArrayEmptyArgs(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	a := ctx.Const(ctx.Symbol("a"), ctx.ArraySort(intTyp, intTyp))
+	one := ctx.Int(1, intTyp)
+	f := ctx.FuncDecl(ctx.Symbol("f"), nil, intTyp)
+
+	// Z3 rejects these, without an empty slice being indexed first
+	cases := []struct {
+		op string
+		fn func()
+	}{
+		{"Context.Map", func() { ctx.Map(f) }},
+		{"Context.Lambda", func() { ctx.Lambda(nil, one) }},
+		{"AST.SelectN", func() { a.SelectN() }},
+		{"AST.StoreN", func() { a.StoreN(nil, one) }},
+		{"Context.ArraySortN", func() { ctx.ArraySortN(nil, intTyp) }},
+	}
+	for _, c := range cases {
+		var z3err *Z3Error
+		if err := ctx.Try(c.fn); !errors.As(err, &z3err) || z3err.Op != c.op {
+			t.Fatalf("%s: bad: %#v", c.op, err)
+		}
+	}
+}
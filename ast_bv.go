@@ -0,0 +1,426 @@
+package z3
+
+import (
+	"math/big"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "go-z3.h"
+import "C"
+
+//-------------------------------------------------------------------
+// Bit-vector Literal Creation
+//-------------------------------------------------------------------
+
+// BitVec creates a bit-vector numeral from an unsigned value. The value
+// is truncated to the width of typ, which must be a bit-vector sort.
+//
+// Maps to: Z3_mk_unsigned_int64
+func (c *Context) BitVec(v uint64, typ *Sort) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_unsigned_int64(c.rawCtx, C.uint64_t(v), typ.rawSort))
+}
+
+// BitVecBig creates a bit-vector numeral from an arbitrary precision
+// integer. Negative values are encoded in two's complement and the value
+// is truncated to the width of typ, which must be a bit-vector sort.
+//
+// Maps to: Z3_mk_numeral
+func (c *Context) BitVecBig(v *big.Int, typ *Sort) *AST {
+	// Reduce the value modulo 2^width so Z3 always sees the unsigned
+	// representation of the bit pattern.
+	mod := new(big.Int).Lsh(big.NewInt(1), typ.BVSize())
+	n := new(big.Int).Mod(v, mod)
+
+	ns := C.CString(n.String())
+	defer C.free(unsafe.Pointer(ns))
+
+	return newAST(c.rawCtx, C.Z3_mk_numeral(c.rawCtx, ns, typ.rawSort))
+}
+
+//-------------------------------------------------------------------
+// Bit-vector Arithmetic
+//-------------------------------------------------------------------
+
+// BVAdd creates an AST node representing two's complement addition.
+//
+// Maps to: Z3_mk_bvadd
+func (a *AST) BVAdd(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvadd(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVSub creates an AST node representing two's complement subtraction.
+//
+// Maps to: Z3_mk_bvsub
+func (a *AST) BVSub(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsub(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVMul creates an AST node representing two's complement multiplication.
+//
+// Maps to: Z3_mk_bvmul
+func (a *AST) BVMul(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvmul(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVNeg creates an AST node representing two's complement unary minus.
+//
+// Maps to: Z3_mk_bvneg
+func (a *AST) BVNeg() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvneg(a.rawCtx, a.rawAST))
+}
+
+// BVUDiv creates an AST node representing unsigned division.
+//
+// Maps to: Z3_mk_bvudiv
+func (a *AST) BVUDiv(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvudiv(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVSDiv creates an AST node representing signed division.
+//
+// Maps to: Z3_mk_bvsdiv
+func (a *AST) BVSDiv(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsdiv(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVURem creates an AST node representing unsigned remainder.
+//
+// Maps to: Z3_mk_bvurem
+func (a *AST) BVURem(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvurem(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVSRem creates an AST node representing signed remainder, where the
+// sign follows the dividend.
+//
+// Maps to: Z3_mk_bvsrem
+func (a *AST) BVSRem(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsrem(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVSMod creates an AST node representing signed remainder, where the
+// sign follows the divisor.
+//
+// Maps to: Z3_mk_bvsmod
+func (a *AST) BVSMod(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsmod(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Bit-vector Bitwise Operations
+//-------------------------------------------------------------------
+
+// BVAnd creates an AST node representing bitwise and.
+//
+// Maps to: Z3_mk_bvand
+func (a *AST) BVAnd(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvand(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVOr creates an AST node representing bitwise or.
+//
+// Maps to: Z3_mk_bvor
+func (a *AST) BVOr(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvor(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVXor creates an AST node representing bitwise exclusive or.
+//
+// Maps to: Z3_mk_bvxor
+func (a *AST) BVXor(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvxor(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVNand creates an AST node representing bitwise nand.
+//
+// Maps to: Z3_mk_bvnand
+func (a *AST) BVNand(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvnand(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVNor creates an AST node representing bitwise nor.
+//
+// Maps to: Z3_mk_bvnor
+func (a *AST) BVNor(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvnor(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVXnor creates an AST node representing bitwise xnor.
+//
+// Maps to: Z3_mk_bvxnor
+func (a *AST) BVXnor(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvxnor(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVNot creates an AST node representing bitwise negation.
+//
+// Maps to: Z3_mk_bvnot
+func (a *AST) BVNot() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvnot(a.rawCtx, a.rawAST))
+}
+
+// BVRedAnd creates an AST node representing the conjunction of all bits,
+// as a bit-vector of width 1.
+//
+// Maps to: Z3_mk_bvredand
+func (a *AST) BVRedAnd() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvredand(a.rawCtx, a.rawAST))
+}
+
+// BVRedOr creates an AST node representing the disjunction of all bits,
+// as a bit-vector of width 1.
+//
+// Maps to: Z3_mk_bvredor
+func (a *AST) BVRedOr() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvredor(a.rawCtx, a.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Bit-vector Shifts and Rotations
+//-------------------------------------------------------------------
+
+// BVShl creates an AST node representing a shift left by a2 bits.
+//
+// Maps to: Z3_mk_bvshl
+func (a *AST) BVShl(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvshl(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVLShr creates an AST node representing a logical shift right by a2
+// bits, filling with zeros.
+//
+// Maps to: Z3_mk_bvlshr
+func (a *AST) BVLShr(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvlshr(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVAShr creates an AST node representing an arithmetic shift right by
+// a2 bits, filling with the sign bit.
+//
+// Maps to: Z3_mk_bvashr
+func (a *AST) BVAShr(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvashr(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVRotateLeft creates an AST node representing a rotation to the left
+// by a constant number of bits.
+//
+// Maps to: Z3_mk_rotate_left
+func (a *AST) BVRotateLeft(i uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_rotate_left(a.rawCtx, C.uint(i), a.rawAST))
+}
+
+// BVRotateRight creates an AST node representing a rotation to the right
+// by a constant number of bits.
+//
+// Maps to: Z3_mk_rotate_right
+func (a *AST) BVRotateRight(i uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_rotate_right(a.rawCtx, C.uint(i), a.rawAST))
+}
+
+// BVExtRotateLeft creates an AST node representing a rotation to the left
+// by a2 bits, where a2 is a bit-vector term.
+//
+// Maps to: Z3_mk_ext_rotate_left
+func (a *AST) BVExtRotateLeft(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_ext_rotate_left(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVExtRotateRight creates an AST node representing a rotation to the
+// right by a2 bits, where a2 is a bit-vector term.
+//
+// Maps to: Z3_mk_ext_rotate_right
+func (a *AST) BVExtRotateRight(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_ext_rotate_right(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Bit-vector Width Changes
+//-------------------------------------------------------------------
+
+// Concat creates an AST node representing the concatenation of a and
+// a2. The bits of a become the most significant bits of the result.
+//
+// Maps to: Z3_mk_concat
+func (a *AST) Concat(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_concat(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// Extract creates an AST node representing the bits high down to low
+// (both inclusive), giving a bit-vector of width high-low+1.
+//
+// Maps to: Z3_mk_extract
+func (a *AST) Extract(high, low uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_extract(a.rawCtx, C.uint(high), C.uint(low), a.rawAST))
+}
+
+// ZeroExt creates an AST node representing a extended with i zero bits.
+//
+// Maps to: Z3_mk_zero_ext
+func (a *AST) ZeroExt(i uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_zero_ext(a.rawCtx, C.uint(i), a.rawAST))
+}
+
+// SignExt creates an AST node representing a extended with i copies of
+// its sign bit.
+//
+// Maps to: Z3_mk_sign_ext
+func (a *AST) SignExt(i uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_sign_ext(a.rawCtx, C.uint(i), a.rawAST))
+}
+
+// BVRepeat creates an AST node representing a concatenated with itself
+// i times.
+//
+// Maps to: Z3_mk_repeat
+func (a *AST) BVRepeat(i uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_repeat(a.rawCtx, C.uint(i), a.rawAST))
+}
+
+// BV2Int creates an AST node converting the bit-vector a to an integer.
+// If signed is true the bit-vector is interpreted in two's complement.
+//
+// Maps to: Z3_mk_bv2int
+func (a *AST) BV2Int(signed bool) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bv2int(a.rawCtx, a.rawAST, C.bool(signed)))
+}
+
+// Int2BV creates an AST node converting the integer a to a bit-vector of
+// the given width.
+//
+// Maps to: Z3_mk_int2bv
+func (a *AST) Int2BV(width uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_int2bv(a.rawCtx, C.uint(width), a.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Bit-vector Comparisons
+//-------------------------------------------------------------------
+
+// ULt creates an unsigned "less than" comparison.
+//
+// Maps to: Z3_mk_bvult
+func (a *AST) ULt(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvult(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// ULe creates an unsigned "less or equal than" comparison.
+//
+// Maps to: Z3_mk_bvule
+func (a *AST) ULe(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvule(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// UGt creates an unsigned "greater than" comparison.
+//
+// Maps to: Z3_mk_bvugt
+func (a *AST) UGt(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvugt(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// UGe creates an unsigned "greater or equal than" comparison.
+//
+// Maps to: Z3_mk_bvuge
+func (a *AST) UGe(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvuge(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// SLt creates a signed "less than" comparison.
+//
+// Maps to: Z3_mk_bvslt
+func (a *AST) SLt(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvslt(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// SLe creates a signed "less or equal than" comparison.
+//
+// Maps to: Z3_mk_bvsle
+func (a *AST) SLe(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsle(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// SGt creates a signed "greater than" comparison.
+//
+// Maps to: Z3_mk_bvsgt
+func (a *AST) SGt(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsgt(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// SGe creates a signed "greater or equal than" comparison.
+//
+// Maps to: Z3_mk_bvsge
+func (a *AST) SGe(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsge(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Bit-vector Overflow Predicates
+//-------------------------------------------------------------------
+
+// BVAddNoOverflow creates a predicate that holds if a + a2 does not
+// overflow. If signed is false the check is for unsigned overflow.
+//
+// Maps to: Z3_mk_bvadd_no_overflow
+func (a *AST) BVAddNoOverflow(a2 *AST, signed bool) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvadd_no_overflow(
+		a.rawCtx, a.rawAST, a2.rawAST, C.bool(signed)))
+}
+
+// BVAddNoUnderflow creates a predicate that holds if the signed addition
+// a + a2 does not underflow.
+//
+// Maps to: Z3_mk_bvadd_no_underflow
+func (a *AST) BVAddNoUnderflow(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvadd_no_underflow(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVSubNoOverflow creates a predicate that holds if the signed
+// subtraction a - a2 does not overflow.
+//
+// Maps to: Z3_mk_bvsub_no_overflow
+func (a *AST) BVSubNoOverflow(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsub_no_overflow(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVSubNoUnderflow creates a predicate that holds if a - a2 does not
+// underflow. If signed is false the check is for unsigned underflow.
+//
+// Maps to: Z3_mk_bvsub_no_underflow
+func (a *AST) BVSubNoUnderflow(a2 *AST, signed bool) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsub_no_underflow(
+		a.rawCtx, a.rawAST, a2.rawAST, C.bool(signed)))
+}
+
+// BVSDivNoOverflow creates a predicate that holds if the signed division
+// a / a2 does not overflow.
+//
+// Maps to: Z3_mk_bvsdiv_no_overflow
+func (a *AST) BVSDivNoOverflow(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvsdiv_no_overflow(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// BVNegNoOverflow creates a predicate that holds if the signed negation
+// of a does not overflow.
+//
+// Maps to: Z3_mk_bvneg_no_overflow
+func (a *AST) BVNegNoOverflow() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvneg_no_overflow(a.rawCtx, a.rawAST))
+}
+
+// BVMulNoOverflow creates a predicate that holds if a * a2 does not
+// overflow. If signed is false the check is for unsigned overflow.
+//
+// Maps to: Z3_mk_bvmul_no_overflow
+func (a *AST) BVMulNoOverflow(a2 *AST, signed bool) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvmul_no_overflow(
+		a.rawCtx, a.rawAST, a2.rawAST, C.bool(signed)))
+}
+
+// BVMulNoUnderflow creates a predicate that holds if the signed
+// multiplication a * a2 does not underflow.
+//
+// Maps to: Z3_mk_bvmul_no_underflow
+func (a *AST) BVMulNoUnderflow(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_bvmul_no_underflow(a.rawCtx, a.rawAST, a2.rawAST))
+}
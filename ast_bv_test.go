@@ -0,0 +1,117 @@
+package z3
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBitVecSort(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	if v := ctx.BitVecSort(32).BVSize(); v != 32 {
+		t.Fatalf("bad: %d", v)
+	}
+}
+
+func TestBitVecNumeral(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	bv8 := ctx.BitVecSort(8)
+
+	if actual := ctx.BitVec(255, bv8).String(); actual != "#xff" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	// Negative values are stored in two's complement
+	if actual := ctx.BitVecBig(big.NewInt(-1), bv8).String(); actual != "#xff" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestASTBVAdd(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.BitVecSort(8))
+	y := ctx.Const(ctx.Symbol("y"), ctx.BitVecSort(8))
+
+	raw := x.BVAdd(y)
+
+	actual := raw.String()
+	if actual != "(bvadd x y)" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestASTExtract(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.BitVecSort(32))
+
+	raw := x.Extract(7, 0).ZeroExt(8)
+
+	actual := raw.String()
+	if actual != "((_ zero_extend 8) ((_ extract 7 0) x))" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestBVWraparound(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	bv8 := ctx.BitVecSort(8)
+	x := ctx.Const(ctx.Symbol("x"), bv8)
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	// x + 1 == 0 with x > 0 (unsigned) only holds for x == 255
+	s.Assert(x.BVAdd(ctx.BitVec(1, bv8)).Eq(ctx.BitVec(0, bv8)))
+	s.Assert(x.UGt(ctx.BitVec(0, bv8)))
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+	if v := m.Eval(x).String(); v != "#xff" {
+		t.Fatalf("bad: %s", v)
+	}
+}
+
+func TestBVOverflow(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	bv8 := ctx.BitVecSort(8)
+	x := ctx.Const(ctx.Symbol("x"), bv8)
+	y := ctx.BitVec(1, bv8)
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	// Signed x + 1 overflows only for x == 127, and 127 < 0 is false
+	s.Assert(x.BVAddNoOverflow(y, true).Not())
+	s.Assert(x.SLt(ctx.BitVec(0, bv8)))
+
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+}
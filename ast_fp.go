@@ -0,0 +1,411 @@
+package z3
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// #include "go-z3.h"
+import "C"
+
+//-------------------------------------------------------------------
+// Floating-point Literal Creation
+//-------------------------------------------------------------------
+
+// RoundingMode creates the AST for the given rounding mode, to be passed
+// to the floating-point operations that round their result.
+//
+// Maps to: Z3_mk_fpa_rne, Z3_mk_fpa_rna, Z3_mk_fpa_rtp, Z3_mk_fpa_rtn,
+// Z3_mk_fpa_rtz
+func (c *Context) RoundingMode(rm RoundingMode) *AST {
+	var raw C.Z3_ast
+	switch rm {
+	case RoundNearestTiesToEven:
+		raw = C.Z3_mk_fpa_rne(c.rawCtx)
+	case RoundNearestTiesToAway:
+		raw = C.Z3_mk_fpa_rna(c.rawCtx)
+	case RoundTowardPositive:
+		raw = C.Z3_mk_fpa_rtp(c.rawCtx)
+	case RoundTowardNegative:
+		raw = C.Z3_mk_fpa_rtn(c.rawCtx)
+	case RoundTowardZero:
+		raw = C.Z3_mk_fpa_rtz(c.rawCtx)
+	default:
+		panic("Unknown RoundingMode")
+	}
+
+	return newAST(c.rawCtx, raw)
+}
+
+// FP creates a floating-point numeral of type typ from a float64. The
+// value is rounded to nearest, ties to even, if typ is narrower than
+// double precision. NaN and infinities are preserved.
+//
+// Maps to: Z3_mk_fpa_numeral_double
+func (c *Context) FP(v float64, typ *Sort) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_fpa_numeral_double(c.rawCtx, C.double(v), typ.rawSort))
+}
+
+// FP32 creates a floating-point numeral of type typ from a float32.
+//
+// Maps to: Z3_mk_fpa_numeral_float
+func (c *Context) FP32(v float32, typ *Sort) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_fpa_numeral_float(c.rawCtx, C.float(v), typ.rawSort))
+}
+
+// FPNaN creates a NaN of type typ.
+//
+// Maps to: Z3_mk_fpa_nan
+func (c *Context) FPNaN(typ *Sort) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_fpa_nan(c.rawCtx, typ.rawSort))
+}
+
+// FPInf creates a positive or negative infinity of type typ.
+//
+// Maps to: Z3_mk_fpa_inf
+func (c *Context) FPInf(typ *Sort, negative bool) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_fpa_inf(c.rawCtx, typ.rawSort, C.bool(negative)))
+}
+
+// FPZero creates a positive or negative zero of type typ.
+//
+// Maps to: Z3_mk_fpa_zero
+func (c *Context) FPZero(typ *Sort, negative bool) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_fpa_zero(c.rawCtx, typ.rawSort, C.bool(negative)))
+}
+
+// FPFromParts creates a floating-point term from its sign (a bit-vector
+// of width 1), biased exponent and significand without the hidden bit.
+//
+// Maps to: Z3_mk_fpa_fp
+func (c *Context) FPFromParts(sgn, exp, sig *AST) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_fpa_fp(c.rawCtx, sgn.rawAST, exp.rawAST, sig.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Floating-point Arithmetic
+//-------------------------------------------------------------------
+
+// FPAbs creates an AST node representing the absolute value.
+//
+// Maps to: Z3_mk_fpa_abs
+func (a *AST) FPAbs() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_abs(a.rawCtx, a.rawAST))
+}
+
+// FPNeg creates an AST node representing the negation.
+//
+// Maps to: Z3_mk_fpa_neg
+func (a *AST) FPNeg() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_neg(a.rawCtx, a.rawAST))
+}
+
+// FPAdd creates an AST node representing a + a2, rounded with rm.
+//
+// Maps to: Z3_mk_fpa_add
+func (a *AST) FPAdd(rm, a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_add(a.rawCtx, rm.rawAST, a.rawAST, a2.rawAST))
+}
+
+// FPSub creates an AST node representing a - a2, rounded with rm.
+//
+// Maps to: Z3_mk_fpa_sub
+func (a *AST) FPSub(rm, a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_sub(a.rawCtx, rm.rawAST, a.rawAST, a2.rawAST))
+}
+
+// FPMul creates an AST node representing a * a2, rounded with rm.
+//
+// Maps to: Z3_mk_fpa_mul
+func (a *AST) FPMul(rm, a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_mul(a.rawCtx, rm.rawAST, a.rawAST, a2.rawAST))
+}
+
+// FPDiv creates an AST node representing a / a2, rounded with rm.
+//
+// Maps to: Z3_mk_fpa_div
+func (a *AST) FPDiv(rm, a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_div(a.rawCtx, rm.rawAST, a.rawAST, a2.rawAST))
+}
+
+// FPFMA creates an AST node representing the fused multiply-add
+// a * a2 + a3, rounded once with rm.
+//
+// Maps to: Z3_mk_fpa_fma
+func (a *AST) FPFMA(rm, a2, a3 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_fma(a.rawCtx, rm.rawAST, a.rawAST, a2.rawAST, a3.rawAST))
+}
+
+// FPSqrt creates an AST node representing the square root, rounded with rm.
+//
+// Maps to: Z3_mk_fpa_sqrt
+func (a *AST) FPSqrt(rm *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_sqrt(a.rawCtx, rm.rawAST, a.rawAST))
+}
+
+// FPRem creates an AST node representing the IEEE-754 remainder of a / a2.
+//
+// Maps to: Z3_mk_fpa_rem
+func (a *AST) FPRem(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_rem(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// FPRoundToIntegral creates an AST node representing a rounded to an integral
+// floating-point value using rm.
+//
+// Maps to: Z3_mk_fpa_round_to_integral
+func (a *AST) FPRoundToIntegral(rm *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_round_to_integral(a.rawCtx, rm.rawAST, a.rawAST))
+}
+
+// FPMin creates an AST node representing the minimum of a and a2.
+//
+// Maps to: Z3_mk_fpa_min
+func (a *AST) FPMin(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_min(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// FPMax creates an AST node representing the maximum of a and a2.
+//
+// Maps to: Z3_mk_fpa_max
+func (a *AST) FPMax(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_max(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Floating-point Comparisons and Classification
+//-------------------------------------------------------------------
+
+// FPLt creates a floating-point "less than" comparison.
+//
+// Maps to: Z3_mk_fpa_lt
+func (a *AST) FPLt(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_lt(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// FPLe creates a floating-point "less or equal than" comparison.
+//
+// Maps to: Z3_mk_fpa_leq
+func (a *AST) FPLe(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_leq(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// FPGt creates a floating-point "greater than" comparison.
+//
+// Maps to: Z3_mk_fpa_gt
+func (a *AST) FPGt(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_gt(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// FPGe creates a floating-point "greater or equal than" comparison.
+//
+// Maps to: Z3_mk_fpa_geq
+func (a *AST) FPGe(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_geq(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// FPEq creates an IEEE-754 equality comparison. Unlike Eq, NaN is not
+// equal to itself and the two zeros are equal.
+//
+// Maps to: Z3_mk_fpa_eq
+func (a *AST) FPEq(a2 *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_eq(a.rawCtx, a.rawAST, a2.rawAST))
+}
+
+// FPIsNormal creates a predicate that holds if a is a normal number.
+//
+// Maps to: Z3_mk_fpa_is_normal
+func (a *AST) FPIsNormal() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_is_normal(a.rawCtx, a.rawAST))
+}
+
+// FPIsSubnormal creates a predicate that holds if a is a subnormal number.
+//
+// Maps to: Z3_mk_fpa_is_subnormal
+func (a *AST) FPIsSubnormal() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_is_subnormal(a.rawCtx, a.rawAST))
+}
+
+// FPIsZero creates a predicate that holds if a is positive or negative zero.
+//
+// Maps to: Z3_mk_fpa_is_zero
+func (a *AST) FPIsZero() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_is_zero(a.rawCtx, a.rawAST))
+}
+
+// FPIsInfinite creates a predicate that holds if a is an infinity.
+//
+// Maps to: Z3_mk_fpa_is_infinite
+func (a *AST) FPIsInfinite() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_is_infinite(a.rawCtx, a.rawAST))
+}
+
+// FPIsNaN creates a predicate that holds if a is NaN.
+//
+// Maps to: Z3_mk_fpa_is_nan
+func (a *AST) FPIsNaN() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_is_nan(a.rawCtx, a.rawAST))
+}
+
+// FPIsNegative creates a predicate that holds if a is negative and not NaN.
+//
+// Maps to: Z3_mk_fpa_is_negative
+func (a *AST) FPIsNegative() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_is_negative(a.rawCtx, a.rawAST))
+}
+
+// FPIsPositive creates a predicate that holds if a is positive and not NaN.
+//
+// Maps to: Z3_mk_fpa_is_positive
+func (a *AST) FPIsPositive() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_is_positive(a.rawCtx, a.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Floating-point Conversions
+//-------------------------------------------------------------------
+
+// BVToFP creates an AST node reinterpreting the bit-vector a as an
+// IEEE-754 value of type typ. The width of a must be ebits+sbits.
+//
+// Maps to: Z3_mk_fpa_to_fp_bv
+func (a *AST) BVToFP(typ *Sort) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_to_fp_bv(a.rawCtx, a.rawAST, typ.rawSort))
+}
+
+// FPToFP creates an AST node converting the floating-point term a to
+// the floating-point type typ, rounding with rm.
+//
+// Maps to: Z3_mk_fpa_to_fp_float
+func (a *AST) FPToFP(rm *AST, typ *Sort) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_to_fp_float(a.rawCtx, rm.rawAST, a.rawAST, typ.rawSort))
+}
+
+// RealToFP creates an AST node converting the real term a to the
+// floating-point type typ, rounding with rm.
+//
+// Maps to: Z3_mk_fpa_to_fp_real
+func (a *AST) RealToFP(rm *AST, typ *Sort) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_to_fp_real(a.rawCtx, rm.rawAST, a.rawAST, typ.rawSort))
+}
+
+// SBVToFP creates an AST node converting the signed bit-vector a to the
+// floating-point type typ, rounding with rm.
+//
+// Maps to: Z3_mk_fpa_to_fp_signed
+func (a *AST) SBVToFP(rm *AST, typ *Sort) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_to_fp_signed(a.rawCtx, rm.rawAST, a.rawAST, typ.rawSort))
+}
+
+// UBVToFP creates an AST node converting the unsigned bit-vector a to
+// the floating-point type typ, rounding with rm.
+//
+// Maps to: Z3_mk_fpa_to_fp_unsigned
+func (a *AST) UBVToFP(rm *AST, typ *Sort) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_to_fp_unsigned(a.rawCtx, rm.rawAST, a.rawAST, typ.rawSort))
+}
+
+// FPToSBV creates an AST node converting a to a signed bit-vector of
+// the given width, rounding with rm.
+//
+// Maps to: Z3_mk_fpa_to_sbv
+func (a *AST) FPToSBV(rm *AST, width uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_to_sbv(a.rawCtx, rm.rawAST, a.rawAST, C.uint(width)))
+}
+
+// FPToUBV creates an AST node converting a to an unsigned bit-vector of
+// the given width, rounding with rm.
+//
+// Maps to: Z3_mk_fpa_to_ubv
+func (a *AST) FPToUBV(rm *AST, width uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_to_ubv(a.rawCtx, rm.rawAST, a.rawAST, C.uint(width)))
+}
+
+// FPToReal creates an AST node converting a to a real. The result is
+// unspecified for NaN and infinities.
+//
+// Maps to: Z3_mk_fpa_to_real
+func (a *AST) FPToReal() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_to_real(a.rawCtx, a.rawAST))
+}
+
+// FPToIEEEBV creates an AST node converting a to its IEEE-754 bit-vector
+// encoding. The encoding of NaN is unspecified.
+//
+// Maps to: Z3_mk_fpa_to_ieee_bv
+func (a *AST) FPToIEEEBV() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_fpa_to_ieee_bv(a.rawCtx, a.rawAST))
+}
+
+//-------------------------------------------------------------------
+// Floating-point Value Readers
+//-------------------------------------------------------------------
+
+// isFPNumeral reports whether a is a floating-point numeral.
+func (a *AST) isFPNumeral() bool {
+	sort := C.Z3_get_sort(a.rawCtx, a.rawAST)
+	result := C.Z3_get_sort_kind(a.rawCtx, sort) == C.Z3_FLOATING_POINT_SORT &&
+		bool(C.Z3_is_numeral_ast(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// fpValue returns the value of a floating-point numeral. Finite values
+// are returned exactly as a big.Float, which has enough precision for
+// any Z3 floating-point sort; NaN and infinities are returned as float64.
+func (a *AST) fpValue() (*big.Float, float64, error) {
+	if !a.isFPNumeral() {
+		return nil, 0, fmt.Errorf("not a floating-point numeral: %s", a.String())
+	}
+
+	// NaN has no sign, so it must be handled before querying it.
+	if bool(C.Z3_fpa_is_numeral_nan(a.rawCtx, a.rawAST)) {
+		return nil, math.NaN(), nil
+	}
+
+	var sgn C.int
+	C.Z3_fpa_get_numeral_sign(a.rawCtx, a.rawAST, &sgn)
+	checkError(a.rawCtx)
+	switch {
+	case bool(C.Z3_fpa_is_numeral_inf(a.rawCtx, a.rawAST)):
+		if sgn != 0 {
+			return nil, math.Inf(-1), nil
+		}
+		return nil, math.Inf(1), nil
+	case bool(C.Z3_fpa_is_numeral_zero(a.rawCtx, a.rawAST)):
+		if sgn != 0 {
+			return nil, math.Copysign(0, -1), nil
+		}
+		return nil, 0, nil
+	}
+
+	sort := C.Z3_get_sort(a.rawCtx, a.rawAST)
+	ebits := uint(C.Z3_fpa_get_ebits(a.rawCtx, sort))
+	sbits := uint(C.Z3_fpa_get_sbits(a.rawCtx, sort))
+
+	// The significand is reported without the hidden bit.
+	sig, err := newAST(a.rawCtx, C.Z3_fpa_get_numeral_significand_bv(a.rawCtx, a.rawAST)).BigInt()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var exp int64
+	if bool(C.Z3_fpa_is_numeral_subnormal(a.rawCtx, a.rawAST)) {
+		// Subnormals have the minimum exponent and no hidden bit.
+		exp = 2 - int64(1)<<(ebits-1)
+	} else {
+		var e C.int64_t
+		C.Z3_fpa_get_numeral_exponent_int64(a.rawCtx, a.rawAST, &e, C.bool(false))
+		checkError(a.rawCtx)
+		exp = int64(e)
+		sig.SetBit(sig, int(sbits-1), 1)
+	}
+
+	f := new(big.Float).SetPrec(sbits).SetInt(sig)
+	f.SetMantExp(f, int(exp)-int(sbits-1))
+	if sgn != 0 {
+		f.Neg(f)
+	}
+	return f, 0, nil
+}
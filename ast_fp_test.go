@@ -0,0 +1,133 @@
+package z3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFPSort(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	s := ctx.FPSort32()
+	if s.FPEBits() != 8 || s.FPSBits() != 24 {
+		t.Fatalf("bad: %d %d", s.FPEBits(), s.FPSBits())
+	}
+}
+
+func TestFPRoundTrip(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	values := []float64{
+		1.5, -0.1, math.MaxFloat64, math.SmallestNonzeroFloat64,
+		math.Inf(1), math.Inf(-1), math.Copysign(0, -1),
+	}
+	for _, v := range values {
+		f, exact, err := ctx.FP(v, ctx.FPSort64()).Float64()
+		if err != nil || !exact || f != v || math.Signbit(f) != math.Signbit(v) {
+			t.Fatalf("bad: %v => %v %v %v", v, f, exact, err)
+		}
+	}
+
+	f, _, err := ctx.FPNaN(ctx.FPSort64()).Float64()
+	if err != nil || !math.IsNaN(f) {
+		t.Fatalf("bad: %v %v", f, err)
+	}
+
+	f32, exact, err := ctx.FP32(0.1, ctx.FPSort32()).Float32()
+	if err != nil || !exact || f32 != 0.1 {
+		t.Fatalf("bad: %v %v %v", f32, exact, err)
+	}
+}
+
+func TestFPAdd(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	typ := ctx.FPSort64()
+	rne := ctx.RoundingMode(RoundNearestTiesToEven)
+	x := ctx.Const(ctx.Symbol("x"), typ)
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	// 0.1 + 0.2 is not 0.3 in double precision
+	a, b := 0.1, 0.2
+	s.Assert(x.Eq(ctx.FP(a, typ).FPAdd(rne, ctx.FP(b, typ))))
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+	f, _, err := m.Eval(x).Float64()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if f != a+b || f == 0.3 {
+		t.Fatalf("bad: %v", f)
+	}
+}
+
+func TestFPIsSubnormal(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	typ := ctx.FPSort32()
+	x := ctx.Const(ctx.Symbol("x"), typ)
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	s.Assert(x.FPIsSubnormal())
+	s.Assert(x.FPIsPositive())
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+	f, exact, err := m.Eval(x).Float32()
+	if err != nil || !exact {
+		t.Fatalf("bad: %v %v", exact, err)
+	}
+	if f <= 0 || f >= 1.1754944e-38 {
+		t.Fatalf("bad: %v", f)
+	}
+}
+
+func TestFPConversions(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	rtz := ctx.RoundingMode(RoundTowardZero)
+	v := ctx.FP(-2.75, ctx.FPSort64())
+
+	n, err := v.FPToSBV(rtz, 32).Simplify().BV2Int(true).Simplify().Int64()
+	if err != nil || n != -2 {
+		t.Fatalf("bad: %v %v", n, err)
+	}
+
+	r, _, err := v.FPToReal().Simplify().Float64()
+	if err != nil || r != -2.75 {
+		t.Fatalf("bad: %v %v", r, err)
+	}
+
+	bits, err := v.FPToIEEEBV().Simplify().Uint64()
+	if err != nil || bits != math.Float64bits(-2.75) {
+		t.Fatalf("bad: %x %v", bits, err)
+	}
+}
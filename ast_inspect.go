@@ -0,0 +1,546 @@
+package z3
+
+import (
+	"fmt"
+)
+
+// #include "go-z3.h"
+import "C"
+
+// ASTKind is the kind of an AST node.
+type ASTKind int
+
+const (
+	ASTKindNumeral    ASTKind = C.Z3_NUMERAL_AST
+	ASTKindApp        ASTKind = C.Z3_APP_AST
+	ASTKindVar        ASTKind = C.Z3_VAR_AST
+	ASTKindQuantifier ASTKind = C.Z3_QUANTIFIER_AST
+	ASTKindSort       ASTKind = C.Z3_SORT_AST
+	ASTKindFuncDecl   ASTKind = C.Z3_FUNC_DECL_AST
+	ASTKindUnknown    ASTKind = C.Z3_UNKNOWN_AST
+)
+
+func (k ASTKind) String() string {
+	switch k {
+	case ASTKindNumeral:
+		return "Numeral"
+	case ASTKindApp:
+		return "App"
+	case ASTKindVar:
+		return "Var"
+	case ASTKindQuantifier:
+		return "Quantifier"
+	case ASTKindSort:
+		return "Sort"
+	case ASTKindFuncDecl:
+		return "FuncDecl"
+	case ASTKindUnknown:
+		return "Unknown"
+	default:
+		panic("Unknown ASTKind")
+	}
+}
+
+// DeclKind is the kind of a function declaration: the built-in function
+// it is, such as DeclKindAnd or DeclKindBVAdd, or DeclKindUninterpreted for
+// the functions and constants declared by the user. The kinds of proof
+// steps are ProofRules.
+type DeclKind int
+
+const (
+	// Basic
+	DeclKindTrue     DeclKind = C.Z3_OP_TRUE
+	DeclKindFalse    DeclKind = C.Z3_OP_FALSE
+	DeclKindEq       DeclKind = C.Z3_OP_EQ
+	DeclKindDistinct DeclKind = C.Z3_OP_DISTINCT
+	DeclKindIte      DeclKind = C.Z3_OP_ITE
+	DeclKindAnd      DeclKind = C.Z3_OP_AND
+	DeclKindOr       DeclKind = C.Z3_OP_OR
+	DeclKindIff      DeclKind = C.Z3_OP_IFF
+	DeclKindXor      DeclKind = C.Z3_OP_XOR
+	DeclKindNot      DeclKind = C.Z3_OP_NOT
+	DeclKindImplies  DeclKind = C.Z3_OP_IMPLIES
+	DeclKindOeq      DeclKind = C.Z3_OP_OEQ
+
+	// Arithmetic
+	DeclKindANum   DeclKind = C.Z3_OP_ANUM
+	DeclKindAGNum  DeclKind = C.Z3_OP_AGNUM
+	DeclKindLe     DeclKind = C.Z3_OP_LE
+	DeclKindGe     DeclKind = C.Z3_OP_GE
+	DeclKindLt     DeclKind = C.Z3_OP_LT
+	DeclKindGt     DeclKind = C.Z3_OP_GT
+	DeclKindAdd    DeclKind = C.Z3_OP_ADD
+	DeclKindSub    DeclKind = C.Z3_OP_SUB
+	DeclKindUMinus DeclKind = C.Z3_OP_UMINUS
+	DeclKindMul    DeclKind = C.Z3_OP_MUL
+	DeclKindDiv    DeclKind = C.Z3_OP_DIV
+	DeclKindIDiv   DeclKind = C.Z3_OP_IDIV
+	DeclKindRem    DeclKind = C.Z3_OP_REM
+	DeclKindMod    DeclKind = C.Z3_OP_MOD
+	DeclKindToReal DeclKind = C.Z3_OP_TO_REAL
+	DeclKindToInt  DeclKind = C.Z3_OP_TO_INT
+	DeclKindIsInt  DeclKind = C.Z3_OP_IS_INT
+	DeclKindPower  DeclKind = C.Z3_OP_POWER
+
+	// Arrays and sets
+	DeclKindStore         DeclKind = C.Z3_OP_STORE
+	DeclKindSelect        DeclKind = C.Z3_OP_SELECT
+	DeclKindConstArray    DeclKind = C.Z3_OP_CONST_ARRAY
+	DeclKindArrayMap      DeclKind = C.Z3_OP_ARRAY_MAP
+	DeclKindArrayDefault  DeclKind = C.Z3_OP_ARRAY_DEFAULT
+	DeclKindSetUnion      DeclKind = C.Z3_OP_SET_UNION
+	DeclKindSetIntersect  DeclKind = C.Z3_OP_SET_INTERSECT
+	DeclKindSetDifference DeclKind = C.Z3_OP_SET_DIFFERENCE
+	DeclKindSetComplement DeclKind = C.Z3_OP_SET_COMPLEMENT
+	DeclKindSetSubset     DeclKind = C.Z3_OP_SET_SUBSET
+	DeclKindAsArray       DeclKind = C.Z3_OP_AS_ARRAY
+	DeclKindArrayExt      DeclKind = C.Z3_OP_ARRAY_EXT
+	DeclKindSetHasSize    DeclKind = C.Z3_OP_SET_HAS_SIZE
+	DeclKindSetCard       DeclKind = C.Z3_OP_SET_CARD
+
+	// Bit-vectors
+	DeclKindBVNum            DeclKind = C.Z3_OP_BNUM
+	DeclKindBVNeg            DeclKind = C.Z3_OP_BNEG
+	DeclKindBVAdd            DeclKind = C.Z3_OP_BADD
+	DeclKindBVSub            DeclKind = C.Z3_OP_BSUB
+	DeclKindBVMul            DeclKind = C.Z3_OP_BMUL
+	DeclKindBVSDiv           DeclKind = C.Z3_OP_BSDIV
+	DeclKindBVUDiv           DeclKind = C.Z3_OP_BUDIV
+	DeclKindBVSRem           DeclKind = C.Z3_OP_BSREM
+	DeclKindBVURem           DeclKind = C.Z3_OP_BUREM
+	DeclKindBVSMod           DeclKind = C.Z3_OP_BSMOD
+	DeclKindULe              DeclKind = C.Z3_OP_ULEQ
+	DeclKindSLe              DeclKind = C.Z3_OP_SLEQ
+	DeclKindUGe              DeclKind = C.Z3_OP_UGEQ
+	DeclKindSGe              DeclKind = C.Z3_OP_SGEQ
+	DeclKindULt              DeclKind = C.Z3_OP_ULT
+	DeclKindSLt              DeclKind = C.Z3_OP_SLT
+	DeclKindUGt              DeclKind = C.Z3_OP_UGT
+	DeclKindSGt              DeclKind = C.Z3_OP_SGT
+	DeclKindBVAnd            DeclKind = C.Z3_OP_BAND
+	DeclKindBVOr             DeclKind = C.Z3_OP_BOR
+	DeclKindBVNot            DeclKind = C.Z3_OP_BNOT
+	DeclKindBVXor            DeclKind = C.Z3_OP_BXOR
+	DeclKindBVNand           DeclKind = C.Z3_OP_BNAND
+	DeclKindBVNor            DeclKind = C.Z3_OP_BNOR
+	DeclKindBVXnor           DeclKind = C.Z3_OP_BXNOR
+	DeclKindConcat           DeclKind = C.Z3_OP_CONCAT
+	DeclKindSignExt          DeclKind = C.Z3_OP_SIGN_EXT
+	DeclKindZeroExt          DeclKind = C.Z3_OP_ZERO_EXT
+	DeclKindExtract          DeclKind = C.Z3_OP_EXTRACT
+	DeclKindBVRepeat         DeclKind = C.Z3_OP_REPEAT
+	DeclKindBVRedOr          DeclKind = C.Z3_OP_BREDOR
+	DeclKindBVRedAnd         DeclKind = C.Z3_OP_BREDAND
+	DeclKindBVComp           DeclKind = C.Z3_OP_BCOMP
+	DeclKindBVShl            DeclKind = C.Z3_OP_BSHL
+	DeclKindBVLShr           DeclKind = C.Z3_OP_BLSHR
+	DeclKindBVAShr           DeclKind = C.Z3_OP_BASHR
+	DeclKindBVRotateLeft     DeclKind = C.Z3_OP_ROTATE_LEFT
+	DeclKindBVRotateRight    DeclKind = C.Z3_OP_ROTATE_RIGHT
+	DeclKindBVExtRotateLeft  DeclKind = C.Z3_OP_EXT_ROTATE_LEFT
+	DeclKindBVExtRotateRight DeclKind = C.Z3_OP_EXT_ROTATE_RIGHT
+	DeclKindInt2BV           DeclKind = C.Z3_OP_INT2BV
+	DeclKindBV2Int           DeclKind = C.Z3_OP_BV2INT
+
+	// Sequences, strings and regular expressions
+	DeclKindSeqUnit      DeclKind = C.Z3_OP_SEQ_UNIT
+	DeclKindSeqEmpty     DeclKind = C.Z3_OP_SEQ_EMPTY
+	DeclKindSeqConcat    DeclKind = C.Z3_OP_SEQ_CONCAT
+	DeclKindSeqPrefix    DeclKind = C.Z3_OP_SEQ_PREFIX
+	DeclKindSeqSuffix    DeclKind = C.Z3_OP_SEQ_SUFFIX
+	DeclKindSeqContains  DeclKind = C.Z3_OP_SEQ_CONTAINS
+	DeclKindSeqExtract   DeclKind = C.Z3_OP_SEQ_EXTRACT
+	DeclKindSeqReplace   DeclKind = C.Z3_OP_SEQ_REPLACE
+	DeclKindSeqAt        DeclKind = C.Z3_OP_SEQ_AT
+	DeclKindSeqNth       DeclKind = C.Z3_OP_SEQ_NTH
+	DeclKindSeqLength    DeclKind = C.Z3_OP_SEQ_LENGTH
+	DeclKindSeqIndex     DeclKind = C.Z3_OP_SEQ_INDEX
+	DeclKindSeqLastIndex DeclKind = C.Z3_OP_SEQ_LAST_INDEX
+	DeclKindSeqToRe      DeclKind = C.Z3_OP_SEQ_TO_RE
+	DeclKindSeqInRe      DeclKind = C.Z3_OP_SEQ_IN_RE
+	DeclKindStrToInt     DeclKind = C.Z3_OP_STR_TO_INT
+	DeclKindIntToStr     DeclKind = C.Z3_OP_INT_TO_STR
+	DeclKindStringLt     DeclKind = C.Z3_OP_STRING_LT
+	DeclKindStringLe     DeclKind = C.Z3_OP_STRING_LE
+	DeclKindRePlus       DeclKind = C.Z3_OP_RE_PLUS
+	DeclKindReStar       DeclKind = C.Z3_OP_RE_STAR
+	DeclKindReOption     DeclKind = C.Z3_OP_RE_OPTION
+	DeclKindReConcat     DeclKind = C.Z3_OP_RE_CONCAT
+	DeclKindReUnion      DeclKind = C.Z3_OP_RE_UNION
+	DeclKindReRange      DeclKind = C.Z3_OP_RE_RANGE
+	DeclKindReLoop       DeclKind = C.Z3_OP_RE_LOOP
+	DeclKindReIntersect  DeclKind = C.Z3_OP_RE_INTERSECT
+	DeclKindReEmptySet   DeclKind = C.Z3_OP_RE_EMPTY_SET
+	DeclKindReFullSet    DeclKind = C.Z3_OP_RE_FULL_SET
+	DeclKindReComplement DeclKind = C.Z3_OP_RE_COMPLEMENT
+
+	// Datatypes
+	DeclKindDTConstructor DeclKind = C.Z3_OP_DT_CONSTRUCTOR
+	DeclKindDTRecogniser  DeclKind = C.Z3_OP_DT_RECOGNISER
+	DeclKindDTIs          DeclKind = C.Z3_OP_DT_IS
+	DeclKindDTAccessor    DeclKind = C.Z3_OP_DT_ACCESSOR
+	DeclKindDTUpdateField DeclKind = C.Z3_OP_DT_UPDATE_FIELD
+
+	// Floating-point
+	DeclKindFPRoundNearestTiesToEven DeclKind = C.Z3_OP_FPA_RM_NEAREST_TIES_TO_EVEN
+	DeclKindFPRoundNearestTiesToAway DeclKind = C.Z3_OP_FPA_RM_NEAREST_TIES_TO_AWAY
+	DeclKindFPRoundTowardPositive    DeclKind = C.Z3_OP_FPA_RM_TOWARD_POSITIVE
+	DeclKindFPRoundTowardNegative    DeclKind = C.Z3_OP_FPA_RM_TOWARD_NEGATIVE
+	DeclKindFPRoundTowardZero        DeclKind = C.Z3_OP_FPA_RM_TOWARD_ZERO
+	DeclKindFPNum                    DeclKind = C.Z3_OP_FPA_NUM
+	DeclKindFPPlusInf                DeclKind = C.Z3_OP_FPA_PLUS_INF
+	DeclKindFPMinusInf               DeclKind = C.Z3_OP_FPA_MINUS_INF
+	DeclKindFPNaN                    DeclKind = C.Z3_OP_FPA_NAN
+	DeclKindFPPlusZero               DeclKind = C.Z3_OP_FPA_PLUS_ZERO
+	DeclKindFPMinusZero              DeclKind = C.Z3_OP_FPA_MINUS_ZERO
+	DeclKindFPAdd                    DeclKind = C.Z3_OP_FPA_ADD
+	DeclKindFPSub                    DeclKind = C.Z3_OP_FPA_SUB
+	DeclKindFPNeg                    DeclKind = C.Z3_OP_FPA_NEG
+	DeclKindFPMul                    DeclKind = C.Z3_OP_FPA_MUL
+	DeclKindFPDiv                    DeclKind = C.Z3_OP_FPA_DIV
+	DeclKindFPRem                    DeclKind = C.Z3_OP_FPA_REM
+	DeclKindFPAbs                    DeclKind = C.Z3_OP_FPA_ABS
+	DeclKindFPMin                    DeclKind = C.Z3_OP_FPA_MIN
+	DeclKindFPMax                    DeclKind = C.Z3_OP_FPA_MAX
+	DeclKindFPFMA                    DeclKind = C.Z3_OP_FPA_FMA
+	DeclKindFPSqrt                   DeclKind = C.Z3_OP_FPA_SQRT
+	DeclKindFPRoundToIntegral        DeclKind = C.Z3_OP_FPA_ROUND_TO_INTEGRAL
+	DeclKindFPEq                     DeclKind = C.Z3_OP_FPA_EQ
+	DeclKindFPLt                     DeclKind = C.Z3_OP_FPA_LT
+	DeclKindFPGt                     DeclKind = C.Z3_OP_FPA_GT
+	DeclKindFPLe                     DeclKind = C.Z3_OP_FPA_LE
+	DeclKindFPGe                     DeclKind = C.Z3_OP_FPA_GE
+	DeclKindFPIsNaN                  DeclKind = C.Z3_OP_FPA_IS_NAN
+	DeclKindFPIsInf                  DeclKind = C.Z3_OP_FPA_IS_INF
+	DeclKindFPIsZero                 DeclKind = C.Z3_OP_FPA_IS_ZERO
+	DeclKindFPIsNormal               DeclKind = C.Z3_OP_FPA_IS_NORMAL
+	DeclKindFPIsSubnormal            DeclKind = C.Z3_OP_FPA_IS_SUBNORMAL
+	DeclKindFPIsNegative             DeclKind = C.Z3_OP_FPA_IS_NEGATIVE
+	DeclKindFPIsPositive             DeclKind = C.Z3_OP_FPA_IS_POSITIVE
+	DeclKindFPFP                     DeclKind = C.Z3_OP_FPA_FP
+	DeclKindFPToFP                   DeclKind = C.Z3_OP_FPA_TO_FP
+	DeclKindFPToFPUnsigned           DeclKind = C.Z3_OP_FPA_TO_FP_UNSIGNED
+	DeclKindFPToUBV                  DeclKind = C.Z3_OP_FPA_TO_UBV
+	DeclKindFPToSBV                  DeclKind = C.Z3_OP_FPA_TO_SBV
+	DeclKindFPToReal                 DeclKind = C.Z3_OP_FPA_TO_REAL
+	DeclKindFPToIEEEBV               DeclKind = C.Z3_OP_FPA_TO_IEEE_BV
+
+	// Other
+	DeclKindInternal      DeclKind = C.Z3_OP_INTERNAL
+	DeclKindUninterpreted DeclKind = C.Z3_OP_UNINTERPRETED
+)
+
+// declKindNames are the names of the decl kinds, for String.
+var declKindNames = map[DeclKind]string{
+	DeclKindTrue:                     "True",
+	DeclKindFalse:                    "False",
+	DeclKindEq:                       "Eq",
+	DeclKindDistinct:                 "Distinct",
+	DeclKindIte:                      "Ite",
+	DeclKindAnd:                      "And",
+	DeclKindOr:                       "Or",
+	DeclKindIff:                      "Iff",
+	DeclKindXor:                      "Xor",
+	DeclKindNot:                      "Not",
+	DeclKindImplies:                  "Implies",
+	DeclKindOeq:                      "Oeq",
+	DeclKindANum:                     "ANum",
+	DeclKindAGNum:                    "AGNum",
+	DeclKindLe:                       "Le",
+	DeclKindGe:                       "Ge",
+	DeclKindLt:                       "Lt",
+	DeclKindGt:                       "Gt",
+	DeclKindAdd:                      "Add",
+	DeclKindSub:                      "Sub",
+	DeclKindUMinus:                   "UMinus",
+	DeclKindMul:                      "Mul",
+	DeclKindDiv:                      "Div",
+	DeclKindIDiv:                     "IDiv",
+	DeclKindRem:                      "Rem",
+	DeclKindMod:                      "Mod",
+	DeclKindToReal:                   "ToReal",
+	DeclKindToInt:                    "ToInt",
+	DeclKindIsInt:                    "IsInt",
+	DeclKindPower:                    "Power",
+	DeclKindStore:                    "Store",
+	DeclKindSelect:                   "Select",
+	DeclKindConstArray:               "ConstArray",
+	DeclKindArrayMap:                 "ArrayMap",
+	DeclKindArrayDefault:             "ArrayDefault",
+	DeclKindSetUnion:                 "SetUnion",
+	DeclKindSetIntersect:             "SetIntersect",
+	DeclKindSetDifference:            "SetDifference",
+	DeclKindSetComplement:            "SetComplement",
+	DeclKindSetSubset:                "SetSubset",
+	DeclKindAsArray:                  "AsArray",
+	DeclKindArrayExt:                 "ArrayExt",
+	DeclKindSetHasSize:               "SetHasSize",
+	DeclKindSetCard:                  "SetCard",
+	DeclKindBVNum:                    "BVNum",
+	DeclKindBVNeg:                    "BVNeg",
+	DeclKindBVAdd:                    "BVAdd",
+	DeclKindBVSub:                    "BVSub",
+	DeclKindBVMul:                    "BVMul",
+	DeclKindBVSDiv:                   "BVSDiv",
+	DeclKindBVUDiv:                   "BVUDiv",
+	DeclKindBVSRem:                   "BVSRem",
+	DeclKindBVURem:                   "BVURem",
+	DeclKindBVSMod:                   "BVSMod",
+	DeclKindULe:                      "ULe",
+	DeclKindSLe:                      "SLe",
+	DeclKindUGe:                      "UGe",
+	DeclKindSGe:                      "SGe",
+	DeclKindULt:                      "ULt",
+	DeclKindSLt:                      "SLt",
+	DeclKindUGt:                      "UGt",
+	DeclKindSGt:                      "SGt",
+	DeclKindBVAnd:                    "BVAnd",
+	DeclKindBVOr:                     "BVOr",
+	DeclKindBVNot:                    "BVNot",
+	DeclKindBVXor:                    "BVXor",
+	DeclKindBVNand:                   "BVNand",
+	DeclKindBVNor:                    "BVNor",
+	DeclKindBVXnor:                   "BVXnor",
+	DeclKindConcat:                   "Concat",
+	DeclKindSignExt:                  "SignExt",
+	DeclKindZeroExt:                  "ZeroExt",
+	DeclKindExtract:                  "Extract",
+	DeclKindBVRepeat:                 "BVRepeat",
+	DeclKindBVRedOr:                  "BVRedOr",
+	DeclKindBVRedAnd:                 "BVRedAnd",
+	DeclKindBVComp:                   "BVComp",
+	DeclKindBVShl:                    "BVShl",
+	DeclKindBVLShr:                   "BVLShr",
+	DeclKindBVAShr:                   "BVAShr",
+	DeclKindBVRotateLeft:             "BVRotateLeft",
+	DeclKindBVRotateRight:            "BVRotateRight",
+	DeclKindBVExtRotateLeft:          "BVExtRotateLeft",
+	DeclKindBVExtRotateRight:         "BVExtRotateRight",
+	DeclKindInt2BV:                   "Int2BV",
+	DeclKindBV2Int:                   "BV2Int",
+	DeclKindSeqUnit:                  "SeqUnit",
+	DeclKindSeqEmpty:                 "SeqEmpty",
+	DeclKindSeqConcat:                "SeqConcat",
+	DeclKindSeqPrefix:                "SeqPrefix",
+	DeclKindSeqSuffix:                "SeqSuffix",
+	DeclKindSeqContains:              "SeqContains",
+	DeclKindSeqExtract:               "SeqExtract",
+	DeclKindSeqReplace:               "SeqReplace",
+	DeclKindSeqAt:                    "SeqAt",
+	DeclKindSeqNth:                   "SeqNth",
+	DeclKindSeqLength:                "SeqLength",
+	DeclKindSeqIndex:                 "SeqIndex",
+	DeclKindSeqLastIndex:             "SeqLastIndex",
+	DeclKindSeqToRe:                  "SeqToRe",
+	DeclKindSeqInRe:                  "SeqInRe",
+	DeclKindStrToInt:                 "StrToInt",
+	DeclKindIntToStr:                 "IntToStr",
+	DeclKindStringLt:                 "StringLt",
+	DeclKindStringLe:                 "StringLe",
+	DeclKindRePlus:                   "RePlus",
+	DeclKindReStar:                   "ReStar",
+	DeclKindReOption:                 "ReOption",
+	DeclKindReConcat:                 "ReConcat",
+	DeclKindReUnion:                  "ReUnion",
+	DeclKindReRange:                  "ReRange",
+	DeclKindReLoop:                   "ReLoop",
+	DeclKindReIntersect:              "ReIntersect",
+	DeclKindReEmptySet:               "ReEmptySet",
+	DeclKindReFullSet:                "ReFullSet",
+	DeclKindReComplement:             "ReComplement",
+	DeclKindDTConstructor:            "DTConstructor",
+	DeclKindDTRecogniser:             "DTRecogniser",
+	DeclKindDTIs:                     "DTIs",
+	DeclKindDTAccessor:               "DTAccessor",
+	DeclKindDTUpdateField:            "DTUpdateField",
+	DeclKindFPRoundNearestTiesToEven: "FPRoundNearestTiesToEven",
+	DeclKindFPRoundNearestTiesToAway: "FPRoundNearestTiesToAway",
+	DeclKindFPRoundTowardPositive:    "FPRoundTowardPositive",
+	DeclKindFPRoundTowardNegative:    "FPRoundTowardNegative",
+	DeclKindFPRoundTowardZero:        "FPRoundTowardZero",
+	DeclKindFPNum:                    "FPNum",
+	DeclKindFPPlusInf:                "FPPlusInf",
+	DeclKindFPMinusInf:               "FPMinusInf",
+	DeclKindFPNaN:                    "FPNaN",
+	DeclKindFPPlusZero:               "FPPlusZero",
+	DeclKindFPMinusZero:              "FPMinusZero",
+	DeclKindFPAdd:                    "FPAdd",
+	DeclKindFPSub:                    "FPSub",
+	DeclKindFPNeg:                    "FPNeg",
+	DeclKindFPMul:                    "FPMul",
+	DeclKindFPDiv:                    "FPDiv",
+	DeclKindFPRem:                    "FPRem",
+	DeclKindFPAbs:                    "FPAbs",
+	DeclKindFPMin:                    "FPMin",
+	DeclKindFPMax:                    "FPMax",
+	DeclKindFPFMA:                    "FPFMA",
+	DeclKindFPSqrt:                   "FPSqrt",
+	DeclKindFPRoundToIntegral:        "FPRoundToIntegral",
+	DeclKindFPEq:                     "FPEq",
+	DeclKindFPLt:                     "FPLt",
+	DeclKindFPGt:                     "FPGt",
+	DeclKindFPLe:                     "FPLe",
+	DeclKindFPGe:                     "FPGe",
+	DeclKindFPIsNaN:                  "FPIsNaN",
+	DeclKindFPIsInf:                  "FPIsInf",
+	DeclKindFPIsZero:                 "FPIsZero",
+	DeclKindFPIsNormal:               "FPIsNormal",
+	DeclKindFPIsSubnormal:            "FPIsSubnormal",
+	DeclKindFPIsNegative:             "FPIsNegative",
+	DeclKindFPIsPositive:             "FPIsPositive",
+	DeclKindFPFP:                     "FPFP",
+	DeclKindFPToFP:                   "FPToFP",
+	DeclKindFPToFPUnsigned:           "FPToFPUnsigned",
+	DeclKindFPToUBV:                  "FPToUBV",
+	DeclKindFPToSBV:                  "FPToSBV",
+	DeclKindFPToReal:                 "FPToReal",
+	DeclKindFPToIEEEBV:               "FPToIEEEBV",
+	DeclKindInternal:                 "Internal",
+	DeclKindUninterpreted:            "Uninterpreted",
+}
+
+func (k DeclKind) String() string {
+	if name, ok := declKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("DeclKind(%d)", int(k))
+}
+
+// Kind returns the kind of the function declaration.
+//
+// Maps to: Z3_get_decl_kind
+func (f *FuncDecl) Kind() DeclKind {
+	result := DeclKind(C.Z3_get_decl_kind(f.rawCtx, f.rawFuncDecl))
+	checkError(f.rawCtx)
+	return result
+}
+
+// Kind returns the kind of the AST node. Numerals are applications too, so
+// ASTKindNumeral is returned for them rather than ASTKindApp.
+//
+// Maps to: Z3_get_ast_kind
+func (a *AST) Kind() ASTKind {
+	result := ASTKind(C.Z3_get_ast_kind(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// IsApp returns true if the AST is an application of a function
+// declaration, which includes constants and numerals.
+//
+// Maps to: Z3_is_app
+func (a *AST) IsApp() bool {
+	result := bool(C.Z3_is_app(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// Sort returns the sort of an expression: an application, a numeral, a
+// bound variable or a quantifier.
+//
+// Maps to: Z3_get_sort
+func (a *AST) Sort() *Sort {
+	return newSort(a.rawCtx, C.Z3_get_sort(a.rawCtx, a.rawAST))
+}
+
+// NumArgs returns the number of arguments of an application, or 0 if the
+// AST is not an application.
+//
+// Maps to: Z3_get_app_num_args
+func (a *AST) NumArgs() int {
+	if !a.IsApp() {
+		return 0
+	}
+	result := int(C.Z3_get_app_num_args(a.rawCtx, C.Z3_to_app(a.rawCtx, a.rawAST)))
+	checkError(a.rawCtx)
+	return result
+}
+
+// Arg returns the i-th argument of an application. i must be less than
+// NumArgs.
+//
+// Maps to: Z3_get_app_arg
+func (a *AST) Arg(i int) *AST {
+	return newAST(a.rawCtx, C.Z3_get_app_arg(a.rawCtx, C.Z3_to_app(a.rawCtx, a.rawAST), C.uint(i)))
+}
+
+// Args returns the arguments of an application, or nil if the AST is not
+// an application.
+func (a *AST) Args() []*AST {
+	n := a.NumArgs()
+	if n == 0 {
+		return nil
+	}
+
+	result := make([]*AST, n)
+	for i := range result {
+		result[i] = a.Arg(i)
+	}
+	return result
+}
+
+// Decl returns the function declaration of an application, such as the
+// declaration of f for (f x), or of x itself for a constant x.
+//
+// Maps to: Z3_get_app_decl
+func (a *AST) Decl() *FuncDecl {
+	return newFuncDecl(a.rawCtx, C.Z3_get_app_decl(a.rawCtx, C.Z3_to_app(a.rawCtx, a.rawAST)))
+}
+
+// IsTrue returns true if the AST is the constant true.
+func (a *AST) IsTrue() bool {
+	return a.IsApp() && a.Decl().Kind() == DeclKindTrue
+}
+
+// IsFalse returns true if the AST is the constant false.
+func (a *AST) IsFalse() bool {
+	return a.IsApp() && a.Decl().Kind() == DeclKindFalse
+}
+
+// IsNumeral returns true if the AST is a numeral, such as 3, 1/2, #x0f or
+// a floating-point number.
+//
+// Maps to: Z3_is_numeral_ast
+func (a *AST) IsNumeral() bool {
+	result := bool(C.Z3_is_numeral_ast(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// IsConst returns true if the AST is an application without arguments.
+// That includes the constants declared with Const, as well as true, false
+// and numerals; check that the kind of Decl is DeclKindUninterpreted to
+// only accept the former.
+func (a *AST) IsConst() bool {
+	return a.IsApp() && a.NumArgs() == 0
+}
+
+// ID returns the unique identifier of the AST node within its context.
+// Structurally equal ASTs are the same node, so they have the same ID.
+//
+// Maps to: Z3_get_ast_id
+func (a *AST) ID() uint {
+	result := uint(C.Z3_get_ast_id(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// Hash returns a hash code of the AST, which is the same for structurally
+// equal ASTs.
+//
+// Maps to: Z3_get_ast_hash
+func (a *AST) Hash() uint {
+	result := uint(C.Z3_get_ast_hash(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// Equal returns true if a and b are structurally equal. Unlike Eq, it
+// compares the ASTs themselves, not the values they denote.
+//
+// Maps to: Z3_is_eq_ast
+func (a *AST) Equal(b *AST) bool {
+	result := bool(C.Z3_is_eq_ast(a.rawCtx, a.rawAST, b.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
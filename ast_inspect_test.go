@@ -0,0 +1,95 @@
+package z3
+
+import (
+	"testing"
+)
+
+func TestASTInspect(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{intTyp, intTyp}, intTyp)
+	one := ctx.Int(1, intTyp)
+
+	app := f.Apply(x, one)
+	if k := app.Kind(); k != ASTKindApp {
+		t.Fatalf("bad: %s", k)
+	}
+	if app.NumArgs() != 2 || !app.Arg(0).Equal(x) || !app.Arg(1).Equal(one) {
+		t.Fatalf("bad: %v", app.Args())
+	}
+	if d := app.Decl(); d.Name().String() != "f" || d.Kind() != DeclKindUninterpreted {
+		t.Fatalf("bad: %s %s", d, d.Kind())
+	}
+	if s := app.Sort(); s.String() != "Int" {
+		t.Fatalf("bad: %s", s)
+	}
+
+	sum := x.Add(one).Gt(x)
+	if k := sum.Decl().Kind(); k != DeclKindGt {
+		t.Fatalf("bad: %s", k)
+	}
+	if k := sum.Arg(0).Decl().Kind(); k != DeclKindAdd || k.String() != "Add" {
+		t.Fatalf("bad: %s", k)
+	}
+
+	if k := one.Kind(); k != ASTKindNumeral || !one.IsNumeral() || !one.IsConst() {
+		t.Fatalf("bad: %s", k)
+	}
+	if !x.IsConst() || x.IsNumeral() || app.IsConst() {
+		t.Fatal("bad IsConst")
+	}
+	if !ctx.True().IsTrue() || ctx.True().IsFalse() || !ctx.False().IsFalse() || x.IsTrue() {
+		t.Fatal("bad IsTrue")
+	}
+
+	if k := intTyp.AST().Kind(); k != ASTKindSort || intTyp.AST().NumArgs() != 0 {
+		t.Fatalf("bad: %s", k)
+	}
+	if k := f.AST().Kind(); k != ASTKindFuncDecl {
+		t.Fatalf("bad: %s", k)
+	}
+	if k := DeclKind(-1).String(); k != "DeclKind(-1)" {
+		t.Fatalf("bad: %s", k)
+	}
+
+	// Structurally equal ASTs are the same node
+	other := f.Apply(ctx.Const(ctx.Symbol("x"), intTyp), ctx.Int(1, intTyp))
+	if !other.Equal(app) || other.ID() != app.ID() || other.Hash() != app.Hash() {
+		t.Fatal("should be equal")
+	}
+	if app.Equal(x) || app.ID() == x.ID() {
+		t.Fatal("should not be equal")
+	}
+}
+
+func TestASTInspectSimplified(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	a := ctx.Const(ctx.Symbol("a"), ctx.IntSort())
+	b := ctx.Const(ctx.Symbol("b"), ctx.BoolSort())
+	f := a.Gt(ctx.Int(6, ctx.IntSort())).And(b).And(ctx.True()).Simplify()
+
+	// (and (not (<= a 6)) b)
+	if k := f.Decl().Kind(); k != DeclKindAnd {
+		t.Fatalf("bad: %s in %s", k, f)
+	}
+	args := f.Args()
+	if len(args) != 2 || !args[1].Equal(b) {
+		t.Fatalf("bad: %s", f)
+	}
+	if k := args[0].Decl().Kind(); k != DeclKindNot {
+		t.Fatalf("bad: %s in %s", k, f)
+	}
+	le := args[0].Arg(0)
+	if le.Decl().Kind() != DeclKindLe || le.Arg(1).Int() != 6 {
+		t.Fatalf("bad: %s", le)
+	}
+}
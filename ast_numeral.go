@@ -0,0 +1,158 @@
+package z3
+
+import (
+	"fmt"
+	"math/big"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "go-z3.h"
+import "C"
+
+//-------------------------------------------------------------------
+// Arbitrary Precision Literal Creation
+//-------------------------------------------------------------------
+
+// Numeral creates a numeral of the given type from its string
+// representation. The string may be a decimal integer ("-42"), a decimal
+// fraction ("1.25") or a ratio ("3/4"); the latter two are only valid for
+// the real sort.
+//
+// Maps to: Z3_mk_numeral
+func (c *Context) Numeral(v string, typ *Sort) *AST {
+	ns := C.CString(v)
+	defer C.free(unsafe.Pointer(ns))
+
+	return newAST(c.rawCtx, C.Z3_mk_numeral(c.rawCtx, ns, typ.rawSort))
+}
+
+// IntBig creates an integer numeral of arbitrary size.
+//
+// Maps to: Z3_mk_numeral
+func (c *Context) IntBig(v *big.Int, typ *Sort) *AST {
+	return c.Numeral(v.String(), typ)
+}
+
+// RealBig creates a real numeral from an arbitrary precision rational.
+//
+// Maps to: Z3_mk_numeral
+func (c *Context) RealBig(v *big.Rat) *AST {
+	return c.Numeral(v.RatString(), c.RealSort())
+}
+
+//-------------------------------------------------------------------
+// Arbitrary Precision Value Readers
+//-------------------------------------------------------------------
+
+// numeralString returns the exact decimal or "p/q" representation of a
+// numeral AST, or an error if the AST is not a numeral.
+func (a *AST) numeralString() (string, error) {
+	if !a.IsNumeral() {
+		return "", fmt.Errorf("not a numeral: %s", a.String())
+	}
+	s := C.GoString(C.Z3_get_numeral_string(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return s, nil
+}
+
+// BigRat returns the exact value of a numeral AST as a rational. Integer,
+// real and bit-vector numerals are supported; bit-vectors are read as
+// unsigned values.
+//
+// Maps to: Z3_get_numeral_string
+func (a *AST) BigRat() (*big.Rat, error) {
+	s, err := a.numeralString()
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse numeral %q", s)
+	}
+	return r, nil
+}
+
+// BigInt returns the exact value of a numeral AST as an integer. An error
+// is returned if the AST is not a numeral or is not integral.
+//
+// Maps to: Z3_get_numeral_string
+func (a *AST) BigInt() (*big.Int, error) {
+	r, err := a.BigRat()
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("numeral %s is not an integer", r.RatString())
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// Int64 returns the value of a numeral AST as an int64. Unlike Int, an
+// error is returned rather than silently truncating values that do not fit.
+func (a *AST) Int64() (int64, error) {
+	v, err := a.BigInt()
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("numeral %s overflows int64", v)
+	}
+	return v.Int64(), nil
+}
+
+// Uint64 returns the value of a numeral AST as a uint64. An error is
+// returned for negative values and values that do not fit.
+func (a *AST) Uint64() (uint64, error) {
+	v, err := a.BigInt()
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsUint64() {
+		return 0, fmt.Errorf("numeral %s overflows uint64", v)
+	}
+	return v.Uint64(), nil
+}
+
+// Float64 returns the float64 closest to the value of a numeral AST, and
+// whether that float64 represents the value exactly. Both real and
+// floating-point numerals are supported; NaN, infinities and signed zeros
+// of floating-point numerals are preserved.
+func (a *AST) Float64() (f float64, exact bool, err error) {
+	if a.isFPNumeral() {
+		bf, special, err := a.fpValue()
+		if err != nil || bf == nil {
+			return special, err == nil, err
+		}
+		f, acc := bf.Float64()
+		return f, acc == big.Exact, nil
+	}
+
+	r, err := a.BigRat()
+	if err != nil {
+		return 0, false, err
+	}
+	f, exact = r.Float64()
+	return f, exact, nil
+}
+
+// Float32 returns the float32 closest to the value of a numeral AST, and
+// whether that float32 represents the value exactly. See Float64.
+func (a *AST) Float32() (f float32, exact bool, err error) {
+	if a.isFPNumeral() {
+		bf, special, err := a.fpValue()
+		if err != nil || bf == nil {
+			return float32(special), err == nil, err
+		}
+		f, acc := bf.Float32()
+		return f, acc == big.Exact, nil
+	}
+
+	r, err := a.BigRat()
+	if err != nil {
+		return 0, false, err
+	}
+	f, exact = r.Float32()
+	return f, exact, nil
+}
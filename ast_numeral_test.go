@@ -0,0 +1,173 @@
+package z3
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestIntBig(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	v, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	raw := ctx.IntBig(v, ctx.IntSort())
+
+	actual := raw.String()
+	if actual != "123456789012345678901234567890" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	back, err := raw.BigInt()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if back.Cmp(v) != 0 {
+		t.Fatalf("bad: %s", back)
+	}
+
+	if _, err := raw.Int64(); err == nil {
+		t.Fatal("should overflow int64")
+	}
+}
+
+func TestRealBig(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	raw := ctx.RealBig(big.NewRat(-3, 4))
+
+	r, err := raw.BigRat()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if r.Cmp(big.NewRat(-3, 4)) != 0 {
+		t.Fatalf("bad: %s", r)
+	}
+
+	if _, err := raw.BigInt(); err == nil {
+		t.Fatal("should not be an integer")
+	}
+
+	if r, _ := ctx.Numeral("1.25", ctx.RealSort()).BigRat(); r.Cmp(big.NewRat(5, 4)) != 0 {
+		t.Fatalf("bad: %s", r)
+	}
+}
+
+func TestNumeralReaders(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	if _, err := x.BigInt(); err == nil {
+		t.Fatal("should not be a numeral")
+	}
+
+	neg := ctx.Numeral("-1", ctx.IntSort())
+	if v, err := neg.Int64(); err != nil || v != -1 {
+		t.Fatalf("bad: %d %v", v, err)
+	}
+	if _, err := neg.Uint64(); err == nil {
+		t.Fatal("should not fit in uint64")
+	}
+
+	// Int takes all the bits of int, which are 64 on most platforms
+	large := int64(1) << 40
+	if v, err := ctx.Int(int(large), ctx.IntSort()).Int64(); err != nil || v != int64(int(large)) {
+		t.Fatalf("bad: %d %v", v, err)
+	}
+	if v := ctx.Int(int(large), ctx.IntSort()).Int(); v != int(large) {
+		t.Fatalf("bad: %d", v)
+	}
+	if v, err := ctx.Real(int(large), -3, ctx.RealSort()).BigRat(); err != nil ||
+		v.Cmp(big.NewRat(-int64(int(large)), 3)) != 0 {
+		t.Fatalf("bad: %s %v", v, err)
+	}
+
+	max := ctx.BitVec(1<<64-1, ctx.BitVecSort(64))
+	if v, err := max.Uint64(); err != nil || v != 1<<64-1 {
+		t.Fatalf("bad: %d %v", v, err)
+	}
+}
+
+func TestModelEvalInt64(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	large := ctx.Numeral("10000000000", ctx.IntSort())
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	s.Assert(x.Eq(large.Add(ctx.Int(1, ctx.IntSort()))))
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+	v, err := m.Eval(x).Int64()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v != 10000000001 {
+		t.Fatalf("bad: %d", v)
+	}
+}
+
+func TestFloat(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	if actual := ctx.Float(0.5).String(); actual != "(/ 1.0 2.0)" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	f, exact, err := ctx.Float(-1.75).Float64()
+	if err != nil || !exact || f != -1.75 {
+		t.Fatalf("bad: %v %v %v", f, exact, err)
+	}
+
+	// 1/3 has no exact binary representation
+	f, exact, err = ctx.Real(1, 3, ctx.RealSort()).Float64()
+	if err != nil || exact || f != 1.0/3 {
+		t.Fatalf("bad: %v %v %v", f, exact, err)
+	}
+
+	if _, err := ctx.RealFromFloat(math.NaN()); err == nil {
+		t.Fatal("NaN should not convert")
+	}
+	if _, err := ctx.RealFromFloat(math.Inf(-1)); err == nil {
+		t.Fatal("-Inf should not convert")
+	}
+}
+
+func TestRealSet(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	set := ctx.RealSet(1.5, 2.5)
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	// 2 must not be a member just because 1.5 and 2.5 used to truncate
+	s.Assert(set.Contain(ctx.Float(2)).Or(set.NotContain(ctx.Float(1.5))))
+
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+}
@@ -0,0 +1,343 @@
+package z3
+
+// #include "go-z3.h"
+import "C"
+
+// Pattern is a multi-pattern (trigger) for a quantifier. The quantifier is
+// only instantiated by E-matching with the terms that match all the terms
+// of one of its patterns.
+type Pattern struct {
+	rawCtx     C.Z3_context
+	rawPattern C.Z3_pattern
+}
+
+// newPattern wraps a pattern returned by Z3 and takes a reference to it,
+// like newAST does for ASTs.
+func newPattern(rawCtx C.Z3_context, rawPattern C.Z3_pattern) *Pattern {
+	checkError(rawCtx)
+	p := &Pattern{
+		rawCtx:     rawCtx,
+		rawPattern: rawPattern,
+	}
+	if rawPattern != nil {
+		raw := C.Z3_pattern_to_ast(rawCtx, rawPattern)
+		C.Z3_inc_ref(rawCtx, raw)
+		track(rawCtx, p, func() {
+			C.Z3_dec_ref(rawCtx, raw)
+		})
+	}
+	return p
+}
+
+// QuantifierOptions are the optional attributes of a quantifier.
+type QuantifierOptions struct {
+	// Weight is the instantiation weight of the quantifier. Quantifiers
+	// with a higher weight are instantiated less eagerly. The default
+	// weight is 1.
+	Weight uint
+
+	// ID and SkolemID name the quantifier and its skolem constants, which
+	// helps when reading statistics and models. They may be empty.
+	ID       string
+	SkolemID string
+
+	// Patterns are the triggers of the quantifier. If there are none, Z3
+	// infers them.
+	Patterns []*Pattern
+
+	// NoPatterns are terms that may not be used in inferred triggers.
+	NoPatterns []*AST
+}
+
+// Pattern creates a multi-pattern from the given terms. Each term must be
+// a function application that mentions bound variables.
+//
+// Maps to: Z3_mk_pattern
+func (c *Context) Pattern(terms ...*AST) *Pattern {
+	raws := make([]C.Z3_ast, len(terms))
+	for i, t := range terms {
+		raws[i] = t.rawAST
+	}
+
+	var ptr *C.Z3_ast
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+
+	return newPattern(c.rawCtx, C.Z3_mk_pattern(c.rawCtx, C.uint(len(raws)), ptr))
+}
+
+// String returns a human-friendly string version of the pattern.
+//
+// Maps to: Z3_pattern_to_string
+func (p *Pattern) String() string {
+	result := C.GoString(C.Z3_pattern_to_string(p.rawCtx, p.rawPattern))
+	checkError(p.rawCtx)
+	return result
+}
+
+// Terms returns the terms of the multi-pattern.
+//
+// Maps to: Z3_get_pattern
+func (p *Pattern) Terms() []*AST {
+	n := uint(C.Z3_get_pattern_num_terms(p.rawCtx, p.rawPattern))
+	checkError(p.rawCtx)
+	result := make([]*AST, n)
+	for i := uint(0); i < n; i++ {
+		result[i] = newAST(p.rawCtx, C.Z3_get_pattern(p.rawCtx, p.rawPattern, C.uint(i)))
+	}
+	return result
+}
+
+//-------------------------------------------------------------------
+// Quantifier Creation
+//-------------------------------------------------------------------
+
+// Forall creates a universally quantified formula. The bound constants,
+// created with Const, are abstracted from body.
+//
+// Maps to: Z3_mk_quantifier_const_ex
+func (c *Context) Forall(bound []*AST, body *AST) *AST {
+	return c.quantifierConst(true, bound, body, QuantifierOptions{})
+}
+
+// ForallEx is like Forall, but accepts a weight and patterns.
+//
+// Maps to: Z3_mk_quantifier_const_ex
+func (c *Context) ForallEx(bound []*AST, body *AST, opts QuantifierOptions) *AST {
+	return c.quantifierConst(true, bound, body, opts)
+}
+
+// Exists creates an existentially quantified formula. The bound constants,
+// created with Const, are abstracted from body.
+//
+// Maps to: Z3_mk_quantifier_const_ex
+func (c *Context) Exists(bound []*AST, body *AST) *AST {
+	return c.quantifierConst(false, bound, body, QuantifierOptions{})
+}
+
+// ExistsEx is like Exists, but accepts a weight and patterns.
+//
+// Maps to: Z3_mk_quantifier_const_ex
+func (c *Context) ExistsEx(bound []*AST, body *AST, opts QuantifierOptions) *AST {
+	return c.quantifierConst(false, bound, body, opts)
+}
+
+func (c *Context) quantifierConst(forall bool, bound []*AST, body *AST, opts QuantifierOptions) *AST {
+	raws := make([]C.Z3_app, len(bound))
+	for i, b := range bound {
+		raws[i] = C.Z3_to_app(c.rawCtx, b.rawAST)
+	}
+	var ptr *C.Z3_app
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+
+	patterns, noPatterns := c.quantifierPatterns(opts)
+	return newAST(c.rawCtx, C.Z3_mk_quantifier_const_ex(
+		c.rawCtx,
+		C.bool(forall),
+		C.uint(quantifierWeight(opts)),
+		c.Symbol(opts.ID).rawSymbol,
+		c.Symbol(opts.SkolemID).rawSymbol,
+		C.uint(len(raws)), ptr,
+		C.uint(len(opts.Patterns)), patterns,
+		C.uint(len(opts.NoPatterns)), noPatterns,
+		body.rawAST))
+}
+
+// BoundVar creates a bound variable by its de Bruijn index, for use in
+// the body of a quantifier created with Quantifier. Index 0 refers to the
+// innermost, last declared, bound variable.
+//
+// Maps to: Z3_mk_bound
+func (c *Context) BoundVar(index uint, typ *Sort) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_bound(c.rawCtx, C.uint(index), typ.rawSort))
+}
+
+// Quantifier creates a quantified formula whose body refers to the bound
+// variables by de Bruijn index (see BoundVar). names and sorts describe the
+// bound variables from the outermost to the innermost, and must have the
+// same length.
+//
+// Maps to: Z3_mk_quantifier_ex
+func (c *Context) Quantifier(forall bool, names []*Symbol, sorts []*Sort, body *AST, opts QuantifierOptions) *AST {
+	if len(names) != len(sorts) {
+		panic("Quantifier: names and sorts differ in length")
+	}
+
+	rawNames := make([]C.Z3_symbol, len(names))
+	for i, n := range names {
+		rawNames[i] = n.rawSymbol
+	}
+	rawSorts := make([]C.Z3_sort, len(sorts))
+	for i, s := range sorts {
+		rawSorts[i] = s.rawSort
+	}
+	var namesPtr *C.Z3_symbol
+	var sortsPtr *C.Z3_sort
+	if len(names) > 0 {
+		namesPtr, sortsPtr = &rawNames[0], &rawSorts[0]
+	}
+
+	patterns, noPatterns := c.quantifierPatterns(opts)
+	return newAST(c.rawCtx, C.Z3_mk_quantifier_ex(
+		c.rawCtx,
+		C.bool(forall),
+		C.uint(quantifierWeight(opts)),
+		c.Symbol(opts.ID).rawSymbol,
+		c.Symbol(opts.SkolemID).rawSymbol,
+		C.uint(len(opts.Patterns)), patterns,
+		C.uint(len(opts.NoPatterns)), noPatterns,
+		C.uint(len(sorts)), sortsPtr, namesPtr,
+		body.rawAST))
+}
+
+func quantifierWeight(opts QuantifierOptions) uint {
+	if opts.Weight == 0 {
+		return 1
+	}
+	return opts.Weight
+}
+
+// quantifierPatterns returns the patterns of opts as C arrays.
+func (c *Context) quantifierPatterns(opts QuantifierOptions) (*C.Z3_pattern, *C.Z3_ast) {
+	var patterns *C.Z3_pattern
+	if len(opts.Patterns) > 0 {
+		raws := make([]C.Z3_pattern, len(opts.Patterns))
+		for i, p := range opts.Patterns {
+			raws[i] = p.rawPattern
+		}
+		patterns = &raws[0]
+	}
+
+	var noPatterns *C.Z3_ast
+	if len(opts.NoPatterns) > 0 {
+		raws := make([]C.Z3_ast, len(opts.NoPatterns))
+		for i, p := range opts.NoPatterns {
+			raws[i] = p.rawAST
+		}
+		noPatterns = &raws[0]
+	}
+
+	return patterns, noPatterns
+}
+
+//-------------------------------------------------------------------
+// Quantifier Readers
+//-------------------------------------------------------------------
+
+// IsForall returns true if the AST is a universal quantifier.
+//
+// Maps to: Z3_is_quantifier_forall
+func (a *AST) IsForall() bool {
+	result := a.isQuantifier() && bool(C.Z3_is_quantifier_forall(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// IsExists returns true if the AST is an existential quantifier.
+//
+// Maps to: Z3_is_quantifier_exists
+func (a *AST) IsExists() bool {
+	result := a.isQuantifier() && bool(C.Z3_is_quantifier_exists(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// IsLambda returns true if the AST is a lambda, see Context.Lambda.
+//
+// Maps to: Z3_is_lambda
+func (a *AST) IsLambda() bool {
+	result := a.isQuantifier() && bool(C.Z3_is_lambda(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+func (a *AST) isQuantifier() bool {
+	return a.Kind() == ASTKindQuantifier
+}
+
+// QuantifierBody returns the body of a quantifier, in which the bound
+// variables appear as de Bruijn indices.
+//
+// Maps to: Z3_get_quantifier_body
+func (a *AST) QuantifierBody() *AST {
+	return newAST(a.rawCtx, C.Z3_get_quantifier_body(a.rawCtx, a.rawAST))
+}
+
+// QuantifierNumBound returns the number of variables bound by a
+// quantifier.
+//
+// Maps to: Z3_get_quantifier_num_bound
+func (a *AST) QuantifierNumBound() uint {
+	result := uint(C.Z3_get_quantifier_num_bound(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// QuantifierBoundName returns the name of the i-th bound variable of a
+// quantifier, counting from the outermost.
+//
+// Maps to: Z3_get_quantifier_bound_name
+func (a *AST) QuantifierBoundName(i uint) *Symbol {
+	rawSymbol := C.Z3_get_quantifier_bound_name(a.rawCtx, a.rawAST, C.uint(i))
+	checkError(a.rawCtx)
+	return &Symbol{
+		rawCtx:    a.rawCtx,
+		rawSymbol: rawSymbol,
+	}
+}
+
+// QuantifierBoundSort returns the sort of the i-th bound variable of a
+// quantifier, counting from the outermost.
+//
+// Maps to: Z3_get_quantifier_bound_sort
+func (a *AST) QuantifierBoundSort(i uint) *Sort {
+	return newSort(a.rawCtx, C.Z3_get_quantifier_bound_sort(a.rawCtx, a.rawAST, C.uint(i)))
+}
+
+// QuantifierWeight returns the weight of a quantifier.
+//
+// Maps to: Z3_get_quantifier_weight
+func (a *AST) QuantifierWeight() uint {
+	result := uint(C.Z3_get_quantifier_weight(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// QuantifierPatterns returns the patterns of a quantifier.
+//
+// Maps to: Z3_get_quantifier_pattern_ast
+func (a *AST) QuantifierPatterns() []*Pattern {
+	n := uint(C.Z3_get_quantifier_num_patterns(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	result := make([]*Pattern, n)
+	for i := uint(0); i < n; i++ {
+		result[i] = newPattern(a.rawCtx, C.Z3_get_quantifier_pattern_ast(a.rawCtx, a.rawAST, C.uint(i)))
+	}
+	return result
+}
+
+// QuantifierNoPatterns returns the terms excluded from the patterns of a
+// quantifier.
+//
+// Maps to: Z3_get_quantifier_no_pattern_ast
+func (a *AST) QuantifierNoPatterns() []*AST {
+	n := uint(C.Z3_get_quantifier_num_no_patterns(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	result := make([]*AST, n)
+	for i := uint(0); i < n; i++ {
+		result[i] = newAST(a.rawCtx, C.Z3_get_quantifier_no_pattern_ast(a.rawCtx, a.rawAST, C.uint(i)))
+	}
+	return result
+}
+
+// BoundVarIndex returns the de Bruijn index of a bound variable.
+//
+// Maps to: Z3_get_index_value
+func (a *AST) BoundVarIndex() uint {
+	result := uint(C.Z3_get_index_value(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
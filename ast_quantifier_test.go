@@ -0,0 +1,137 @@
+package z3
+
+import (
+	"testing"
+)
+
+func TestForall(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+
+	raw := ctx.Forall([]*AST{x}, x.Add(ctx.Int(1, intTyp)).Gt(x))
+
+	actual := raw.String()
+	if actual != "(forall ((x Int)) (> (+ x 1) x))" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+	if !raw.IsForall() || raw.IsExists() {
+		t.Fatal("should be a universal quantifier")
+	}
+	if raw.QuantifierNumBound() != 1 {
+		t.Fatalf("bad: %d", raw.QuantifierNumBound())
+	}
+	if v := raw.QuantifierBoundName(0).String(); v != "x" {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := raw.QuantifierBoundSort(0).String(); v != "Int" {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := raw.QuantifierBody().String(); v != "(> (+ (:var 0) 1) (:var 0))" {
+		t.Fatalf("bad: %s", v)
+	}
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	s.Assert(raw.Not())
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+}
+
+func TestExists(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+
+	raw := ctx.Exists([]*AST{x}, x.Mul(x).Eq(ctx.Int(49, intTyp)).And(x.Lt(ctx.Int(0, intTyp))))
+	if !raw.IsExists() {
+		t.Fatal("should be an existential quantifier")
+	}
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	s.Assert(raw)
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+}
+
+func TestForallPatterns(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{intTyp}, intTyp)
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+
+	// forall x :pattern (f x). f(x) > x
+	axiom := ctx.ForallEx([]*AST{x}, f.Apply(x).Gt(x), QuantifierOptions{
+		Weight:   5,
+		ID:       "f_grows",
+		Patterns: []*Pattern{ctx.Pattern(f.Apply(x))},
+	})
+
+	if axiom.QuantifierWeight() != 5 {
+		t.Fatalf("bad: %d", axiom.QuantifierWeight())
+	}
+	patterns := axiom.QuantifierPatterns()
+	if len(patterns) != 1 {
+		t.Fatalf("bad: %v", patterns)
+	}
+	if v := patterns[0].Terms()[0].String(); v != "(f (:var 0))" {
+		t.Fatalf("bad: %s", v)
+	}
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	s.Assert(axiom)
+	s.Assert(f.Apply(ctx.Int(3, intTyp)).Le(ctx.Int(3, intTyp)))
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+}
+
+func TestQuantifierDeBruijn(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.BoundVar(1, intTyp)
+	y := ctx.BoundVar(0, intTyp)
+	if x.BoundVarIndex() != 1 {
+		t.Fatalf("bad: %d", x.BoundVarIndex())
+	}
+
+	// forall x y. x + y = y + x
+	raw := ctx.Quantifier(true,
+		[]*Symbol{ctx.Symbol("x"), ctx.Symbol("y")},
+		[]*Sort{intTyp, intTyp},
+		x.Add(y).Eq(y.Add(x)),
+		QuantifierOptions{})
+
+	actual := raw.String()
+	if actual != "(forall ((x Int) (y Int)) (= (+ x y) (+ y x)))" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	// Every bound variable needs both a name and a sort
+	defer func() {
+		if r := recover(); r != "Quantifier: names and sorts differ in length" {
+			t.Fatalf("bad: %v", r)
+		}
+	}()
+	ctx.Quantifier(true, nil, []*Sort{intTyp}, y.Eq(y), QuantifierOptions{})
+}
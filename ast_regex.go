@@ -0,0 +1,145 @@
+package z3
+
+// #include "go-z3.h"
+import "C"
+
+// Regular expressions have a sort of ReSort and match sequences, usually
+// strings. Use ToRe to create one from a sequence, InRe to check a
+// sequence against one, and ReFromRegexp to convert Go regular
+// expressions.
+
+// ToRe creates the regular expression matching exactly the sequence a.
+//
+// Maps to: Z3_mk_seq_to_re
+func (a *AST) ToRe() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_to_re(a.rawCtx, a.rawAST))
+}
+
+// InRe creates the check that the sequence a matches re in full.
+//
+// Maps to: Z3_mk_seq_in_re
+func (a *AST) InRe(re *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_in_re(a.rawCtx, a.rawAST, re.rawAST))
+}
+
+// ReEmpty creates the regular expression of sort typ matching nothing.
+//
+// Maps to: Z3_mk_re_empty
+func (c *Context) ReEmpty(typ *Sort) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_re_empty(c.rawCtx, typ.rawSort))
+}
+
+// ReFull creates the regular expression of sort typ matching every
+// sequence.
+//
+// Maps to: Z3_mk_re_full
+func (c *Context) ReFull(typ *Sort) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_re_full(c.rawCtx, typ.rawSort))
+}
+
+// ReAllChar creates the regular expression of sort typ matching every
+// sequence of length one.
+//
+// Z3 4.8 only offers re.allchar in SMT-LIB2, so it is taken from a parsed
+// term; typ must be a regular expression sort over strings or sequences
+// of built-in sorts.
+func (c *Context) ReAllChar(typ *Sort) *AST {
+	return c.parseTerm("", "(as re.allchar "+typ.String()+")")
+}
+
+// ReRange creates the regular expression matching the strings of one
+// character between lo and hi inclusive.
+//
+// Maps to: Z3_mk_re_range
+func (c *Context) ReRange(lo, hi rune) *AST {
+	loStr, hiStr := c.runesStr([]rune{lo}), c.runesStr([]rune{hi})
+	return newAST(c.rawCtx, C.Z3_mk_re_range(c.rawCtx, loStr.rawAST, hiStr.rawAST))
+}
+
+// ReUnion creates the regular expression matching what a or any of args
+// match. Without args, it returns a.
+//
+// Maps to: Z3_mk_re_union
+func (a *AST) ReUnion(args ...*AST) *AST {
+	if len(args) == 0 {
+		return a
+	}
+
+	raws := a.rawArgs(args)
+	return newAST(a.rawCtx, C.Z3_mk_re_union(a.rawCtx, C.uint(len(raws)), &raws[0]))
+}
+
+// ReConcat creates the regular expression matching the concatenations of
+// what a and args match, in order. Without args, it returns a.
+//
+// Maps to: Z3_mk_re_concat
+func (a *AST) ReConcat(args ...*AST) *AST {
+	if len(args) == 0 {
+		return a
+	}
+
+	raws := a.rawArgs(args)
+	return newAST(a.rawCtx, C.Z3_mk_re_concat(a.rawCtx, C.uint(len(raws)), &raws[0]))
+}
+
+// ReIntersect creates the regular expression matching what a and all of
+// args match. Without args, it returns a. Z3 decides whether the
+// intersection is empty more easily from a single InRe than from several.
+//
+// Maps to: Z3_mk_re_intersect
+func (a *AST) ReIntersect(args ...*AST) *AST {
+	if len(args) == 0 {
+		return a
+	}
+
+	raws := a.rawArgs(args)
+	return newAST(a.rawCtx, C.Z3_mk_re_intersect(a.rawCtx, C.uint(len(raws)), &raws[0]))
+}
+
+// ReComplement creates the regular expression matching what a does not
+// match.
+//
+// Maps to: Z3_mk_re_complement
+func (a *AST) ReComplement() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_re_complement(a.rawCtx, a.rawAST))
+}
+
+// ReDiff creates the regular expression matching what a matches and b
+// does not.
+//
+// Maps to: Z3_mk_re_intersect, Z3_mk_re_complement
+func (a *AST) ReDiff(b *AST) *AST {
+	return a.ReIntersect(b.ReComplement())
+}
+
+// ReStar creates the regular expression matching zero or more repetitions
+// of a.
+//
+// Maps to: Z3_mk_re_star
+func (a *AST) ReStar() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_re_star(a.rawCtx, a.rawAST))
+}
+
+// RePlus creates the regular expression matching one or more repetitions
+// of a.
+//
+// Maps to: Z3_mk_re_plus
+func (a *AST) RePlus() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_re_plus(a.rawCtx, a.rawAST))
+}
+
+// ReOption creates the regular expression matching a or the empty
+// sequence.
+//
+// Maps to: Z3_mk_re_option
+func (a *AST) ReOption() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_re_option(a.rawCtx, a.rawAST))
+}
+
+// ReLoop creates the regular expression matching between lo and hi
+// repetitions of a, or at least lo if hi is 0.
+//
+// Maps to: Z3_mk_re_loop
+func (a *AST) ReLoop(lo, hi uint) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_re_loop(a.rawCtx, a.rawAST, C.uint(lo), C.uint(hi)))
+}
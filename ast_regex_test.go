@@ -0,0 +1,68 @@
+package z3
+
+import (
+	"testing"
+)
+
+func TestRegex(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	reTyp := ctx.ReSort(ctx.StringSort())
+	if v := reTyp.String(); v != "(RegEx String)" {
+		t.Fatalf("bad: %s", v)
+	}
+
+	ab := ctx.Str("ab").ToRe()
+	digit := ctx.ReRange('0', '9')
+	word := ctx.ReRange('a', 'z').RePlus()
+
+	cases := []struct {
+		re      *AST
+		matches []string
+		others  []string
+	}{
+		{ab.ReStar(), []string{"", "abab"}, []string{"aba"}},
+		{ab.RePlus(), []string{"ab"}, []string{""}},
+		{ab.ReOption(), []string{"", "ab"}, []string{"abab"}},
+		{ab.ReUnion(digit), []string{"ab", "7"}, []string{"ab7"}},
+		{word.ReConcat(digit, digit), []string{"x42"}, []string{"42", "x4"}},
+		{digit.ReLoop(2, 3), []string{"12", "123"}, []string{"1", "1234"}},
+		{digit.ReLoop(2, 0), []string{"12", "12345"}, []string{"1"}},
+		{digit.ReComplement(), []string{"", "12", "a"}, []string{"1"}},
+		{word.ReIntersect(ctx.ReAllChar(reTyp).ReLoop(2, 2)), []string{"ab"}, []string{"a", "abc"}},
+		{word.ReDiff(ab), []string{"abc", "a"}, []string{"ab"}},
+		{ctx.ReAllChar(reTyp), []string{"é", "\n"}, []string{"", "ab"}},
+		{ctx.ReEmpty(reTyp), nil, []string{""}},
+		{ctx.ReFull(reTyp), []string{"", "any"}, nil},
+	}
+	for _, c := range cases {
+		for _, s := range c.matches {
+			if !ctx.Str(s).InRe(c.re).Simplify().IsTrue() {
+				t.Fatalf("%s should match %q", c.re, s)
+			}
+		}
+		for _, s := range c.others {
+			if !ctx.Str(s).InRe(c.re).Simplify().IsFalse() {
+				t.Fatalf("%s should not match %q", c.re, s)
+			}
+		}
+	}
+
+	// Solve for a string matching two expressions
+	x := ctx.Const(ctx.Symbol("x"), ctx.StringSort())
+	s := ctx.MkSolver()
+	defer s.Close()
+	s.Assert(x.InRe(word.ReConcat(digit)))
+	s.Assert(x.InRe(ctx.ReAllChar(reTyp).ReLoop(3, 3)))
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %s", result)
+	}
+	m := s.Model()
+	defer m.Close()
+	if v, err := m.Eval(x).StringValue(); err != nil || len(v) != 3 {
+		t.Fatalf("bad: %q %v", v, err)
+	}
+}
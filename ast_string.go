@@ -0,0 +1,243 @@
+package z3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "go-z3.h"
+import "C"
+
+// Strings are sequences of characters, so the Seq operations below apply
+// to both. Positions and lengths are integers.
+
+// SeqEmpty creates the empty sequence of the given sequence sort, such as
+// StringSort.
+//
+// Maps to: Z3_mk_seq_empty
+func (c *Context) SeqEmpty(typ *Sort) *AST {
+	return newAST(c.rawCtx, C.Z3_mk_seq_empty(c.rawCtx, typ.rawSort))
+}
+
+// SeqUnit creates the sequence holding only a.
+//
+// Maps to: Z3_mk_seq_unit
+func (a *AST) SeqUnit() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_unit(a.rawCtx, a.rawAST))
+}
+
+// SeqConcat creates the concatenation of a and args. Without args, it
+// returns a.
+//
+// Maps to: Z3_mk_seq_concat
+func (a *AST) SeqConcat(args ...*AST) *AST {
+	if len(args) == 0 {
+		return a
+	}
+
+	raws := a.rawArgs(args)
+	return newAST(a.rawCtx, C.Z3_mk_seq_concat(a.rawCtx, C.uint(len(raws)), &raws[0]))
+}
+
+// SeqLength creates the length of a.
+//
+// Maps to: Z3_mk_seq_length
+func (a *AST) SeqLength() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_length(a.rawCtx, a.rawAST))
+}
+
+// SeqExtract creates the subsequence of a that starts at offset and has
+// at most length elements. It is empty if offset is out of bounds.
+//
+// Maps to: Z3_mk_seq_extract
+func (a *AST) SeqExtract(offset, length *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_extract(a.rawCtx, a.rawAST, offset.rawAST, length.rawAST))
+}
+
+// SeqAt creates the sequence holding the element of a at index, or the
+// empty sequence if index is out of bounds.
+//
+// Maps to: Z3_mk_seq_at
+func (a *AST) SeqAt(index *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_at(a.rawCtx, a.rawAST, index.rawAST))
+}
+
+// SeqNth creates the element of a at index, which is unspecified if index
+// is out of bounds.
+//
+// Maps to: Z3_mk_seq_nth
+func (a *AST) SeqNth(index *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_nth(a.rawCtx, a.rawAST, index.rawAST))
+}
+
+// SeqIndexOf creates the first position of substr in a at or after
+// offset, or -1 if there is none.
+//
+// Maps to: Z3_mk_seq_index
+func (a *AST) SeqIndexOf(substr, offset *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_index(a.rawCtx, a.rawAST, substr.rawAST, offset.rawAST))
+}
+
+// SeqLastIndexOf creates the last position of substr in a, or -1 if there
+// is none.
+//
+// Maps to: Z3_mk_seq_last_index
+func (a *AST) SeqLastIndexOf(substr *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_last_index(a.rawCtx, a.rawAST, substr.rawAST))
+}
+
+// SeqContains creates the check that substr occurs in a.
+//
+// Maps to: Z3_mk_seq_contains
+func (a *AST) SeqContains(substr *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_contains(a.rawCtx, a.rawAST, substr.rawAST))
+}
+
+// SeqPrefixOf creates the check that a is a prefix of s.
+//
+// Maps to: Z3_mk_seq_prefix
+func (a *AST) SeqPrefixOf(s *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_prefix(a.rawCtx, a.rawAST, s.rawAST))
+}
+
+// SeqSuffixOf creates the check that a is a suffix of s.
+//
+// Maps to: Z3_mk_seq_suffix
+func (a *AST) SeqSuffixOf(s *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_suffix(a.rawCtx, a.rawAST, s.rawAST))
+}
+
+// SeqReplace creates a with the first occurrence of src replaced by dst.
+//
+// Maps to: Z3_mk_seq_replace
+func (a *AST) SeqReplace(src, dst *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_seq_replace(a.rawCtx, a.rawAST, src.rawAST, dst.rawAST))
+}
+
+// StrReplaceAll creates the string a with every occurrence of src
+// replaced by dst. Unlike SeqReplace, it only applies to strings.
+//
+// Z3 4.8 only offers str.replace_all in SMT-LIB2, so the declaration is
+// taken from a parsed term. Its solver support is limited too: checks
+// involving it on unknown strings often return Undef.
+func (a *AST) StrReplaceAll(src, dst *AST) *AST {
+	ctx := &Context{rawCtx: a.rawCtx}
+	decl := ctx.parseTerm("(declare-const s String)", "(str.replace_all s s s)").Decl()
+	return decl.Apply(a, src, dst)
+}
+
+// StrToInt creates the integer the string a denotes in decimal, or -1 if
+// it is not made of digits only.
+//
+// Maps to: Z3_mk_str_to_int
+func (a *AST) StrToInt() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_str_to_int(a.rawCtx, a.rawAST))
+}
+
+// IntToStr creates the decimal string of the integer a, or the empty
+// string if a is negative.
+//
+// Maps to: Z3_mk_int_to_str
+func (a *AST) IntToStr() *AST {
+	return newAST(a.rawCtx, C.Z3_mk_int_to_str(a.rawCtx, a.rawAST))
+}
+
+// StrLt creates the check that the string a comes before b in
+// lexicographic order.
+//
+// Maps to: Z3_mk_str_lt
+func (a *AST) StrLt(b *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_str_lt(a.rawCtx, a.rawAST, b.rawAST))
+}
+
+// StrLe creates the check that the string a equals b or comes before it
+// in lexicographic order.
+//
+// Maps to: Z3_mk_str_le
+func (a *AST) StrLe(b *AST) *AST {
+	return newAST(a.rawCtx, C.Z3_mk_str_le(a.rawCtx, a.rawAST, b.rawAST))
+}
+
+// IsString returns true if a is a string value, such as one created with
+// Context.Str or a string in a model.
+//
+// Maps to: Z3_is_string
+func (a *AST) IsString() bool {
+	result := bool(C.Z3_is_string(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	return result
+}
+
+// StringValue returns the value of a string value AST as a Go string, or an
+// error if the AST is not a string value. Surrogate code points, which a
+// Go string cannot hold, are read as U+FFFD.
+//
+// Maps to: Z3_get_string
+func (a *AST) StringValue() (string, error) {
+	if !a.IsString() {
+		return "", fmt.Errorf("not a string: %s", a.String())
+	}
+
+	s := C.GoString(C.Z3_get_string(a.rawCtx, a.rawAST))
+	checkError(a.rawCtx)
+	if !strings.ContainsRune(s, '\\') {
+		return s, nil
+	}
+
+	// Z3 escapes characters outside printable ASCII as \u{...} but leaves
+	// backslashes as they are, so that "\u{41}" is either "A" or six
+	// characters. Single characters are unambiguous, so read them one by
+	// one.
+	ctx := &Context{rawCtx: a.rawCtx}
+	n, err := a.SeqLength().Simplify().Int64()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for i := int64(0); i < n; i++ {
+		c := a.SeqAt(ctx.Int(int(i), ctx.IntSort())).Simplify()
+		r, err := unescapeChar(C.GoString(C.Z3_get_string(a.rawCtx, c.rawAST)))
+		checkError(a.rawCtx)
+		if err != nil {
+			return "", err
+		}
+		b.WriteRune(r)
+	}
+	return b.String(), nil
+}
+
+// runesStr creates a string value from runes, which unlike a Go string
+// may hold surrogate code points.
+func (c *Context) runesStr(rs []rune) *AST {
+	var b strings.Builder
+	for _, r := range rs {
+		if r >= ' ' && r <= '~' && r != '\\' {
+			b.WriteRune(r)
+		} else {
+			fmt.Fprintf(&b, "\\u{%x}", r)
+		}
+	}
+
+	cstr := C.CString(b.String())
+	defer C.free(unsafe.Pointer(cstr))
+
+	return newAST(c.rawCtx, C.Z3_mk_string(c.rawCtx, cstr))
+}
+
+// unescapeChar returns the character printed by Z3_get_string for a
+// string of length one.
+func unescapeChar(s string) (rune, error) {
+	if len(s) == 1 {
+		return rune(s[0]), nil
+	}
+	if strings.HasPrefix(s, "\\u{") && strings.HasSuffix(s, "}") {
+		if v, err := strconv.ParseUint(s[3:len(s)-1], 16, 32); err == nil {
+			return rune(v), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot parse character %q", s)
+}
@@ -0,0 +1,137 @@
+package z3
+
+import (
+	"testing"
+)
+
+func TestStringValue(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	for _, s := range []string{
+		"", "abc", "say \"hi\"", "tab\there\n", "é😀", `\u{41}`, `a\b\`, "\x7f\x00", "\U0002ffff",
+	} {
+		a := ctx.Str(s)
+		if !a.IsString() {
+			t.Fatalf("%q: should be a string", s)
+		}
+		v, err := a.StringValue()
+		if err != nil {
+			t.Fatalf("%q: err: %s", s, err)
+		}
+		if v != s {
+			t.Fatalf("bad: %q != %q", v, s)
+		}
+	}
+
+	// Characters count as one each
+	n, err := ctx.Str("é😀").SeqLength().Simplify().Int64()
+	if err != nil || n != 2 {
+		t.Fatalf("bad: %d %v", n, err)
+	}
+
+	if _, err := ctx.Int(1, ctx.IntSort()).StringValue(); err == nil {
+		t.Fatal("should fail")
+	}
+}
+
+func TestStringOps(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	i := func(v int) *AST { return ctx.Int(v, intTyp) }
+	s := ctx.Str("a,b,c")
+	comma := ctx.Str(",")
+
+	strs := map[string]*AST{
+		"a,b,c,d": s.SeqConcat(comma, ctx.Str("d")),
+		"b,c":     s.SeqExtract(i(2), i(10)),
+		"c":       s.SeqAt(i(4)),
+		"":        s.SeqAt(i(5)),
+		"a;b,c":   s.SeqReplace(comma, ctx.Str(";")),
+		"a;b;c":   s.StrReplaceAll(comma, ctx.Str(";")),
+		"42":      i(42).IntToStr(),
+		"x":       ctx.Str("x").SeqConcat(ctx.SeqEmpty(ctx.StringSort())),
+	}
+	for want, a := range strs {
+		if v, err := a.Simplify().StringValue(); err != nil || v != want {
+			t.Fatalf("%s: bad: %q %v", a, v, err)
+		}
+	}
+
+	ints := map[int64]*AST{
+		5:  s.SeqLength(),
+		3:  s.SeqIndexOf(comma, i(2)),
+		-1: s.SeqIndexOf(ctx.Str(";"), i(0)),
+		1:  s.SeqLastIndexOf(ctx.Str(",b")),
+		12: ctx.Str("12").StrToInt(),
+	}
+	for want, a := range ints {
+		if v, err := a.Simplify().Int64(); err != nil || v != want {
+			t.Fatalf("%s: bad: %d %v", a, v, err)
+		}
+	}
+
+	bools := map[*AST]bool{
+		s.SeqContains(ctx.Str("b,")):       true,
+		ctx.Str("a,").SeqPrefixOf(s):       true,
+		ctx.Str("b").SeqSuffixOf(s):        false,
+		ctx.Str("ab").StrLt(ctx.Str("b")):  true,
+		ctx.Str("b").StrLe(ctx.Str("ab")):  false,
+		ctx.Str("ab").StrLe(ctx.Str("ab")): true,
+	}
+	for a, want := range bools {
+		if v := a.Simplify().IsTrue(); v != want {
+			t.Fatalf("%s: bad: %v", a, v)
+		}
+	}
+
+	// Sequences of other sorts
+	seq := i(1).SeqUnit().SeqConcat(i(2).SeqUnit())
+	if v, err := seq.SeqNth(i(1)).Simplify().Int64(); err != nil || v != 2 {
+		t.Fatalf("bad: %d %v", v, err)
+	}
+	if v := ctx.SeqEmpty(seq.Sort()).String(); v != "(as seq.empty (Seq Int))" {
+		t.Fatalf("bad: %s", v)
+	}
+}
+
+func TestStringModel(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	// Find an input that passes a sanitizer removing the first tag only
+	x := ctx.Const(ctx.Symbol("x"), ctx.StringSort())
+	clean := x.SeqReplace(ctx.Str("<script>"), ctx.Str(""))
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	s.Assert(clean.SeqContains(ctx.Str("<script>")))
+	s.Assert(ctx.Str("é").SeqPrefixOf(x))
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %s", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+	v, err := m.Eval(x).StringValue()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v[:2] != "é" {
+		t.Fatalf("bad: %q", v)
+	}
+
+	// The value is a counterexample
+	s.Assert(x.Eq(ctx.Str(v)))
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %s", result)
+	}
+}
@@ -0,0 +1,91 @@
+package z3
+
+// #include "go-z3.h"
+import "C"
+
+// Substitute replaces every occurrence of from[i] in a with to[i]. The
+// replacements are simultaneous, so terms introduced by to are not
+// replaced again. from and to must have the same length, and from[i] and
+// to[i] the same sort.
+//
+// Maps to: Z3_substitute
+func (a *AST) Substitute(from, to []*AST) *AST {
+	if len(from) != len(to) {
+		panic("Substitute: from and to differ in length")
+	}
+
+	rawFrom := make([]C.Z3_ast, len(from))
+	rawTo := make([]C.Z3_ast, len(to))
+	for i := range from {
+		rawFrom[i] = from[i].rawAST
+		rawTo[i] = to[i].rawAST
+	}
+	var fromPtr, toPtr *C.Z3_ast
+	if len(from) > 0 {
+		fromPtr, toPtr = &rawFrom[0], &rawTo[0]
+	}
+	return newAST(a.rawCtx, C.Z3_substitute(a.rawCtx, a.rawAST, C.uint(len(from)), fromPtr, toPtr))
+}
+
+// SubstituteMap replaces every occurrence of a key of m in a with its
+// value, like Substitute does.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (a *AST) SubstituteMap(m map[*AST]*AST) *AST {
+	from := make([]*AST, 0, len(m))
+	to := make([]*AST, 0, len(m))
+	for k, v := range m {
+		from = append(from, k)
+		to = append(to, v)
+	}
+	return a.Substitute(from, to)
+}
+
+// SubstituteVars replaces the free bound variable with de Bruijn index i
+// in a with to[i] (see BoundVar). Use it to instantiate the body of a
+// quantifier or a function interpretation.
+//
+// Maps to: Z3_substitute_vars
+func (a *AST) SubstituteVars(to []*AST) *AST {
+	raws := make([]C.Z3_ast, len(to))
+	for i, t := range to {
+		raws[i] = t.rawAST
+	}
+	var ptr *C.Z3_ast
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+	return newAST(a.rawCtx, C.Z3_substitute_vars(a.rawCtx, a.rawAST, C.uint(len(raws)), ptr))
+}
+
+// SubstituteFuncs replaces every application of from[i] in a with to[i],
+// in which the bound variable with de Bruijn index j stands for argument
+// j of the application (see BoundVar). The arguments are substituted
+// first. from and to must have the same length, and to[i] the range sort
+// of from[i].
+//
+// This doesn't map to any specific Z3 API; it is built on Rewrite and
+// SubstituteVars.
+func (a *AST) SubstituteFuncs(from []*FuncDecl, to []*AST) *AST {
+	if len(from) != len(to) {
+		panic("SubstituteFuncs: from and to differ in length")
+	}
+
+	// Declarations are ASTs too and so have unique IDs.
+	bodies := make(map[uint]*AST, len(from))
+	for i, f := range from {
+		bodies[f.AST().ID()] = to[i]
+	}
+
+	return Rewrite(a, func(a *AST) (*AST, bool) {
+		if !a.IsApp() {
+			return nil, false
+		}
+		body, ok := bodies[a.Decl().AST().ID()]
+		if !ok {
+			return nil, false
+		}
+		return body.SubstituteVars(a.Args()), true
+	})
+}
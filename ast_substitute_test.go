@@ -0,0 +1,63 @@
+package z3
+
+import (
+	"testing"
+)
+
+func TestSubstitute(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	y := ctx.Const(ctx.Symbol("y"), intTyp)
+	one := ctx.Int(1, intTyp)
+
+	// The replacements are simultaneous
+	a := x.Add(one).Lt(y)
+	if v := a.Substitute([]*AST{x, y}, []*AST{y, x}).String(); v != "(< (+ y 1) x)" {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := a.SubstituteMap(map[*AST]*AST{x.Add(one): y}).String(); v != "(< y y)" {
+		t.Fatalf("bad: %s", v)
+	}
+	if !a.Substitute(nil, nil).Equal(a) {
+		t.Fatal("should be unchanged")
+	}
+
+	// Instantiate a template for several components
+	tmpl := ctx.BoundVar(0, intTyp).Gt(ctx.BoundVar(1, intTyp))
+	for i, v := range []string{"(> x 0)", "(> y 1)"} {
+		c := []*AST{x, y}[i]
+		if s := tmpl.SubstituteVars([]*AST{c, ctx.Int(i, intTyp)}).String(); s != v {
+			t.Fatalf("bad: %s", s)
+		}
+	}
+}
+
+func TestSubstituteFuncs(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{intTyp, intTyp}, intTyp)
+	g := ctx.FuncDecl(ctx.Symbol("g"), []*Sort{intTyp}, intTyp)
+
+	// f(a, b) = a - b and g(a) = f(a, a), with nested applications
+	sub := ctx.BoundVar(0, intTyp).Sub(ctx.BoundVar(1, intTyp))
+	a := f.Apply(g.Apply(x), x).Eq(x)
+	result := a.SubstituteFuncs([]*FuncDecl{f}, []*AST{sub})
+	if v := result.String(); v != "(= (- (g x) x) x)" {
+		t.Fatalf("bad: %s", v)
+	}
+
+	result = a.SubstituteFuncs([]*FuncDecl{f, g}, []*AST{sub, x.Mul(ctx.BoundVar(0, intTyp))})
+	if v := result.String(); v != "(= (- (* x x) x) x)" {
+		t.Fatalf("bad: %s", v)
+	}
+}
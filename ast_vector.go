@@ -0,0 +1,19 @@
+package z3
+
+// #include "go-z3.h"
+import "C"
+
+// astVectorToSlice copies the elements of an AST vector into a Go slice
+// and releases the vector. The vector must not have been referenced yet.
+func astVectorToSlice(rawCtx C.Z3_context, vec C.Z3_ast_vector) []*AST {
+	C.Z3_ast_vector_inc_ref(rawCtx, vec)
+	defer C.Z3_ast_vector_dec_ref(rawCtx, vec)
+
+	n := uint(C.Z3_ast_vector_size(rawCtx, vec))
+	checkError(rawCtx)
+	result := make([]*AST, n)
+	for i := uint(0); i < n; i++ {
+		result[i] = newAST(rawCtx, C.Z3_ast_vector_get(rawCtx, vec, C.uint(i)))
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package z3
+
+// #include "go-z3.h"
+import "C"
+
+// Visitor is called by Walk for the nodes of an AST.
+type Visitor interface {
+	// Pre is called for a node before its children are visited. If it
+	// returns false, the children are skipped and Post is not called for
+	// the node.
+	Pre(a *AST) bool
+
+	// Post is called for a node after its children were visited.
+	Post(a *AST)
+}
+
+// Walk visits the nodes of a in depth-first order, calling v.Pre before
+// and v.Post after visiting the children of a node. The children of an
+// application are its arguments and the child of a quantifier is its
+// body. A node shared by several parents, as determined by ID, is visited
+// once.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func Walk(a *AST, v Visitor) {
+	visited := make(map[uint]bool)
+
+	var walk func(a *AST)
+	walk = func(a *AST) {
+		id := a.ID()
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+
+		if !v.Pre(a) {
+			return
+		}
+		for _, child := range a.children() {
+			walk(child)
+		}
+		v.Post(a)
+	}
+	walk(a)
+}
+
+// Rewrite rewrites a bottom-up: the children of a node are rewritten
+// first, the node is rebuilt with Update if any of them changed, and then
+// f is called with the rebuilt node. If f returns true, its result
+// replaces the node, otherwise the rebuilt node is kept. The result of f
+// is not rewritten again.
+//
+// A node shared by several parents, as determined by ID, is rewritten
+// once. The replacement of a node must have the same sort, unless it is
+// the root.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func Rewrite(a *AST, f func(*AST) (*AST, bool)) *AST {
+	memo := make(map[uint]*AST)
+
+	var rewrite func(a *AST) *AST
+	rewrite = func(a *AST) *AST {
+		id := a.ID()
+		if result, ok := memo[id]; ok {
+			return result
+		}
+
+		children := a.children()
+		changed := false
+		for i, child := range children {
+			children[i] = rewrite(child)
+			changed = changed || children[i].ID() != child.ID()
+		}
+
+		result := a
+		if changed {
+			result = a.Update(children...)
+		}
+		if r, ok := f(result); ok {
+			result = r
+		}
+		memo[id] = result
+		return result
+	}
+	return rewrite(a)
+}
+
+// Update returns a copy of an application with the given arguments, or of
+// a quantifier with the given body. There must be as many arguments as
+// NumArgs, with the same sorts. Z3 may normalize the result, such as by
+// flattening nested conjunctions.
+//
+// Maps to: Z3_update_term
+func (a *AST) Update(args ...*AST) *AST {
+	raws := make([]C.Z3_ast, len(args))
+	for i, arg := range args {
+		raws[i] = arg.rawAST
+	}
+
+	var ptr *C.Z3_ast
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+	return newAST(a.rawCtx, C.Z3_update_term(a.rawCtx, a.rawAST, C.uint(len(raws)), ptr))
+}
+
+// children returns the arguments of an application or the body of a
+// quantifier.
+func (a *AST) children() []*AST {
+	switch a.Kind() {
+	case ASTKindApp:
+		return a.Args()
+	case ASTKindQuantifier:
+		return []*AST{a.QuantifierBody()}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+package z3
+
+import (
+	"testing"
+)
+
+type countVisitor struct {
+	pre, post []string
+	skip      DeclKind
+}
+
+func (v *countVisitor) Pre(a *AST) bool {
+	v.pre = append(v.pre, a.String())
+	return !a.IsApp() || a.Decl().Kind() != v.skip
+}
+
+func (v *countVisitor) Post(a *AST) {
+	v.post = append(v.post, a.String())
+}
+
+func TestWalk(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	y := ctx.Const(ctx.Symbol("y"), intTyp)
+
+	// x+y is shared and visited once
+	sum := x.Add(y)
+	a := sum.Gt(x).And(sum.Lt(y))
+
+	v := &countVisitor{skip: -1}
+	Walk(a, v)
+	if len(v.pre) != 6 || len(v.post) != 6 {
+		t.Fatalf("bad: %v", v.pre)
+	}
+	if v.pre[0] != a.String() || v.pre[1] != "(> (+ x y) x)" || v.pre[2] != "(+ x y)" {
+		t.Fatalf("bad: %v", v.pre)
+	}
+	if v.post[0] != "x" || v.post[5] != a.String() {
+		t.Fatalf("bad: %v", v.post)
+	}
+
+	// The children of skipped nodes are not visited
+	v = &countVisitor{skip: DeclKindAdd}
+	Walk(a, v)
+	if len(v.pre) != 6 || len(v.post) != 5 {
+		t.Fatalf("bad: %v %v", v.pre, v.post)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	p := ctx.Const(ctx.Symbol("p"), ctx.BoolSort())
+	q := ctx.Const(ctx.Symbol("q"), ctx.BoolSort())
+	r := ctx.Const(ctx.Symbol("r"), ctx.BoolSort())
+
+	// Push negations into disjunctions; Z3 flattens the rebuilt conjunction
+	a := p.And(q.Or(r).Not())
+	result := Rewrite(a, func(a *AST) (*AST, bool) {
+		if !a.IsApp() || a.Decl().Kind() != DeclKindNot {
+			return nil, false
+		}
+		arg := a.Arg(0)
+		if !arg.IsApp() || arg.Decl().Kind() != DeclKindOr {
+			return nil, false
+		}
+		args := arg.Args()
+		for i := range args {
+			args[i] = args[i].Not()
+		}
+		return args[0].And(args[1:]...), true
+	})
+	if v := result.String(); v != "(and p (not q) (not r))" {
+		t.Fatalf("bad: %s", v)
+	}
+
+	// Unchanged terms are returned as they are
+	if !Rewrite(a, func(*AST) (*AST, bool) { return nil, false }).Equal(a) {
+		t.Fatal("should be unchanged")
+	}
+}
+
+func TestRewriteRename(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	y := ctx.Const(ctx.Symbol("y"), intTyp)
+	z := ctx.Const(ctx.Symbol("z"), intTyp)
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{intTyp}, intTyp)
+
+	// Rename x to z, also under a quantifier
+	a := ctx.Forall([]*AST{y}, f.Apply(y).Gt(x)).And(x.Lt(y))
+	result := Rewrite(a, func(a *AST) (*AST, bool) {
+		if a.Equal(x) {
+			return z, true
+		}
+		return nil, false
+	})
+	if v := result.Arg(1).String(); v != "(< z y)" {
+		t.Fatalf("bad: %s", v)
+	}
+	body := result.Arg(0).QuantifierBody()
+	if body.Arg(1).String() != "z" || body.Arg(0).Arg(0).Kind() != ASTKindVar {
+		t.Fatalf("bad: %s", body)
+	}
+
+	// Update rebuilds applications with new arguments
+	if v := f.Apply(x).Update(z).String(); v != "(f z)" {
+		t.Fatalf("bad: %s", v)
+	}
+}
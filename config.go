@@ -1,6 +1,7 @@
 package z3
 
 import (
+	"runtime"
 	"unsafe"
 )
 
@@ -9,7 +10,8 @@ import (
 import "C"
 
 // Config is used to set configuration for Z3. This should be created with
-// MkConfig and closed with Close when you're done using it.
+// MkConfig and closed with Close when you're done using it. A Config that
+// is not closed is freed once it is garbage collected.
 //
 // Config structures are used to set parameters for Z3 behavior. See the
 // Z3 docs for information on available parameters. They can be set with
@@ -21,14 +23,22 @@ type Config struct {
 
 // MkConfig allocates a new configuration object.
 func MkConfig() *Config {
-	return &Config{
+	c := &Config{
 		raw: C.Z3_mk_config(),
 	}
+	runtime.SetFinalizer(c, (*Config).Close)
+	return c
 }
 
 // Close frees the memory associated with this configuration
 func (c *Config) Close() error {
+	if c.raw == nil {
+		return nil
+	}
+
+	runtime.SetFinalizer(c, nil)
 	C.Z3_del_config(c.raw)
+	c.raw = nil
 	return nil
 }
 
@@ -4,13 +4,27 @@ package z3
 import "C"
 
 // Context is what handles most of the interactions with Z3.
+//
+// The values created from a context, such as AST, Solver or Model, hold a
+// reference to their Z3 object that is released once they are garbage
+// collected, so closing them is optional. The releases are made by the
+// goroutine using the context, the next time it creates a value.
+//
+// The values do not keep the context alive, so a Context must still be
+// closed with Close when you're done with it and all its values.
 type Context struct {
 	rawCtx C.Z3_context
 }
 
+// MkContext creates a new context with the given configuration. The
+// configuration can be closed once the context is created.
+//
+// Maps to: Z3_mk_context_rc
 func MkContext(c *Config) *Context {
+	rawCtx := C.Z3_mk_context_rc(c.Z3Value())
+	registerContextRefs(rawCtx)
 	return &Context{
-		rawCtx: C.Z3_mk_context(c.Z3Value()),
+		rawCtx: rawCtx,
 	}
 }
 
@@ -37,16 +51,21 @@ Legal parameters are:
 // maps to: Z3_update_param_value
 func (c *Context) UpdateParamValue(id, value string) {
 	C.Z3_update_param_value(c.rawCtx, C.CString(id), C.CString(value))
+	checkError(c.rawCtx)
 }
 
 // Close frees the memory associated with this context.
 func (c *Context) Close() error {
+	// Drop the pending releases, deleting the context frees everything
+	unregisterContextRefs(c.rawCtx)
+
 	// Clear context
 	C.Z3_del_context(c.rawCtx)
 
 	// Clear error handling
 	errorHandlerMapLock.Lock()
 	delete(errorHandlerMap, c.rawCtx)
+	c.setErrorModeLocked(ErrorModeHandler)
 	errorHandlerMapLock.Unlock()
 
 	return nil
@@ -60,46 +79,21 @@ func (c *Context) Close() error {
 //
 // Maps to: Z3_tactic_using_params
 func (t *Tactic) With(p *Params) *Tactic {
-	rawTactic := C.Z3_tactic_using_params(t.rawCtx, t.rawTactic, p.rawParams)
-	C.Z3_tactic_inc_ref(t.rawCtx, rawTactic)
-	return &Tactic{
-		rawCtx: t.rawCtx,
-		rawTactic: rawTactic,
-	}
+	return newTactic(t.rawCtx, C.Z3_tactic_using_params(t.rawCtx, t.rawTactic, p.rawParams))
 }
 
 func (c *Context) AndThen(t1, t2 *Tactic) *Tactic {
-	rawTactic := C.Z3_tactic_and_then(c.rawCtx, t1.rawTactic, t2.rawTactic)
-	C.Z3_tactic_inc_ref(c.rawCtx, rawTactic)
-	return &Tactic{
-		rawCtx: c.rawCtx,
-		rawTactic: rawTactic,
-	}
+	return newTactic(c.rawCtx, C.Z3_tactic_and_then(c.rawCtx, t1.rawTactic, t2.rawTactic))
 }
 func (c *Context) ParAndThen(t1, t2 *Tactic) *Tactic {
-	rawTactic := C.Z3_tactic_par_and_then(c.rawCtx, t1.rawTactic, t2.rawTactic)
-	C.Z3_tactic_inc_ref(c.rawCtx, rawTactic)
-	return &Tactic{
-		rawCtx: c.rawCtx,
-		rawTactic: rawTactic,
-	}
+	return newTactic(c.rawCtx, C.Z3_tactic_par_and_then(c.rawCtx, t1.rawTactic, t2.rawTactic))
 
 }
 func (c *Context) OrElse(t1, t2 *Tactic) *Tactic {
-	rawTactic := C.Z3_tactic_or_else(c.rawCtx, t1.rawTactic, t2.rawTactic)
-	C.Z3_tactic_inc_ref(c.rawCtx, rawTactic)
-	return &Tactic{
-		rawCtx: c.rawCtx,
-		rawTactic: rawTactic,
-	}
+	return newTactic(c.rawCtx, C.Z3_tactic_or_else(c.rawCtx, t1.rawTactic, t2.rawTactic))
 
 }
 func (c *Context) Repeat(t *Tactic, max uint) *Tactic {
-	rawTactic := C.Z3_tactic_repeat(c.rawCtx, t.rawTactic, C.uint(max))
-	C.Z3_tactic_inc_ref(c.rawCtx, rawTactic)
-	return &Tactic{
-		rawCtx: c.rawCtx,
-		rawTactic: rawTactic,
-	}
+	return newTactic(c.rawCtx, C.Z3_tactic_repeat(c.rawCtx, t.rawTactic, C.uint(max)))
 
 }
\ No newline at end of file
@@ -0,0 +1,343 @@
+package z3
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "go-z3.h"
+import "C"
+
+// DatatypeField describes a single field of a datatype constructor.
+//
+// The type of the field is either Sort, or Ref when the field refers to a
+// datatype that is declared in the same MkDatatypes call (including the
+// datatype the field belongs to). Exactly one of them must be set.
+type DatatypeField struct {
+	Name string
+	Sort *Sort
+	Ref  *DatatypeDecl
+}
+
+// DatatypeDecl is the declaration of an algebraic datatype that has not
+// been created yet. It is created with MkDatatypeDecl, filled with
+// Constructor and then turned into a Datatype with MkDatatype or, for
+// mutually recursive types, MkDatatypes.
+type DatatypeDecl struct {
+	name         string
+	constructors []datatypeConstructorDecl
+}
+
+type datatypeConstructorDecl struct {
+	name   string
+	fields []DatatypeField
+}
+
+// MkDatatypeDecl starts the declaration of a datatype with the given name.
+func (c *Context) MkDatatypeDecl(name string) *DatatypeDecl {
+	return &DatatypeDecl{name: name}
+}
+
+// Constructor adds a constructor with the given fields to the datatype.
+// The recognizer for the constructor is named "is-" followed by name.
+// It returns the declaration so calls can be chained.
+func (d *DatatypeDecl) Constructor(name string, fields ...DatatypeField) *DatatypeDecl {
+	d.constructors = append(d.constructors, datatypeConstructorDecl{
+		name:   name,
+		fields: fields,
+	})
+	return d
+}
+
+// MkDatatype creates a single, possibly self-recursive, datatype.
+//
+// Maps to: Z3_mk_datatypes
+func (c *Context) MkDatatype(d *DatatypeDecl) *Datatype {
+	return c.MkDatatypes(d)[0]
+}
+
+// MkDatatypes creates a set of mutually recursive datatypes. The result
+// has one Datatype per declaration, in the same order, and is nil without
+// declarations.
+//
+// Maps to: Z3_mk_datatypes
+func (c *Context) MkDatatypes(decls ...*DatatypeDecl) []*Datatype {
+	if len(decls) == 0 {
+		return nil
+	}
+
+	// Fields refer to the other datatypes by their position in this call.
+	refs := make(map[*DatatypeDecl]int, len(decls))
+	for i, d := range decls {
+		refs[d] = i
+	}
+
+	names := make([]C.Z3_symbol, len(decls))
+	sorts := make([]C.Z3_sort, len(decls))
+	lists := make([]C.Z3_constructor_list, len(decls))
+	var ctors []C.Z3_constructor
+
+	// The constructor objects are only needed to create the sorts, all
+	// the information is available from the sorts afterwards. They are
+	// deleted once the sorts are referenced, or if creating them fails.
+	defer func() {
+		for _, l := range lists {
+			if l != nil {
+				C.Z3_del_constructor_list(c.rawCtx, l)
+			}
+		}
+		for _, ctor := range ctors {
+			C.Z3_del_constructor(c.rawCtx, ctor)
+		}
+	}()
+
+	for i, d := range decls {
+		names[i] = c.Symbol(d.name).rawSymbol
+
+		raws := make([]C.Z3_constructor, len(d.constructors))
+		for j, ctor := range d.constructors {
+			raws[j] = c.mkConstructor(ctor, refs)
+		}
+		ctors = append(ctors, raws...)
+
+		var ptr *C.Z3_constructor
+		if len(raws) > 0 {
+			ptr = &raws[0]
+		}
+		lists[i] = C.Z3_mk_constructor_list(c.rawCtx, C.uint(len(raws)), ptr)
+	}
+
+	C.Z3_mk_datatypes(
+		c.rawCtx,
+		C.uint(len(decls)),
+		(*C.Z3_symbol)(unsafe.Pointer(&names[0])),
+		(*C.Z3_sort)(unsafe.Pointer(&sorts[0])),
+		(*C.Z3_constructor_list)(unsafe.Pointer(&lists[0])))
+	checkError(c.rawCtx)
+
+	// Take references to all the sorts before making more Z3 calls.
+	wrapped := make([]*Sort, len(decls))
+	for i := range decls {
+		wrapped[i] = newSort(c.rawCtx, sorts[i])
+	}
+
+	result := make([]*Datatype, len(decls))
+	for i, s := range wrapped {
+		result[i] = s.Datatype()
+	}
+	return result
+}
+
+func (c *Context) mkConstructor(d datatypeConstructorDecl, refs map[*DatatypeDecl]int) C.Z3_constructor {
+	n := len(d.fields)
+	names := make([]C.Z3_symbol, n)
+	sorts := make([]C.Z3_sort, n)
+	sortRefs := make([]C.uint, n)
+	for i, f := range d.fields {
+		names[i] = c.Symbol(f.Name).rawSymbol
+		if f.Sort != nil {
+			sorts[i] = f.Sort.rawSort
+		} else {
+			idx, ok := refs[f.Ref]
+			if !ok {
+				panic(fmt.Sprintf("field %s refers to an undeclared datatype", f.Name))
+			}
+			sortRefs[i] = C.uint(idx)
+		}
+	}
+
+	var namesPtr *C.Z3_symbol
+	var sortsPtr *C.Z3_sort
+	var refsPtr *C.uint
+	if n > 0 {
+		namesPtr, sortsPtr, refsPtr = &names[0], &sorts[0], &sortRefs[0]
+	}
+
+	result := C.Z3_mk_constructor(
+		c.rawCtx,
+		c.Symbol(d.name).rawSymbol,
+		c.Symbol("is-"+d.name).rawSymbol,
+		C.uint(n),
+		namesPtr,
+		sortsPtr,
+		refsPtr)
+	checkError(c.rawCtx)
+	return result
+}
+
+// EnumSort creates an enumeration datatype: a datatype whose constructors
+// are the given values, none of which have fields.
+func (c *Context) EnumSort(name string, values ...string) *Datatype {
+	d := c.MkDatatypeDecl(name)
+	for _, v := range values {
+		d.Constructor(v)
+	}
+	return c.MkDatatype(d)
+}
+
+// TupleSort creates a record datatype with a single constructor, named
+// after the datatype, that has the given fields.
+func (c *Context) TupleSort(name string, fields ...DatatypeField) *Datatype {
+	return c.MkDatatype(c.MkDatatypeDecl(name).Constructor(name, fields...))
+}
+
+//-------------------------------------------------------------------
+// Datatype
+//-------------------------------------------------------------------
+
+// Datatype is an algebraic datatype along with its constructors,
+// recognizers and accessors.
+type Datatype struct {
+	sort         *Sort
+	constructors []*DatatypeConstructor
+}
+
+// DatatypeConstructor is a single constructor of a Datatype.
+type DatatypeConstructor struct {
+	decl      *FuncDecl
+	tester    *FuncDecl
+	accessors []*DatatypeAccessor
+}
+
+// DatatypeAccessor gives access to a single field of the values built by
+// a DatatypeConstructor.
+type DatatypeAccessor struct {
+	decl *FuncDecl
+}
+
+// Datatype returns the constructors, recognizers and accessors of a
+// datatype sort.
+//
+// Maps to: Z3_get_datatype_sort_constructor,
+// Z3_get_datatype_sort_recognizer,
+// Z3_get_datatype_sort_constructor_accessor
+func (s *Sort) Datatype() *Datatype {
+	n := uint(C.Z3_get_datatype_sort_num_constructors(s.rawCtx, s.rawSort))
+	checkError(s.rawCtx)
+	d := &Datatype{
+		sort:         s,
+		constructors: make([]*DatatypeConstructor, n),
+	}
+	for i := uint(0); i < n; i++ {
+		ctor := &DatatypeConstructor{}
+		ctor.decl = newFuncDecl(s.rawCtx, C.Z3_get_datatype_sort_constructor(
+			s.rawCtx, s.rawSort, C.uint(i)))
+		ctor.tester = newFuncDecl(s.rawCtx, C.Z3_get_datatype_sort_recognizer(
+			s.rawCtx, s.rawSort, C.uint(i)))
+
+		nf := ctor.decl.Arity()
+		ctor.accessors = make([]*DatatypeAccessor, nf)
+		for j := 0; j < nf; j++ {
+			ctor.accessors[j] = &DatatypeAccessor{
+				decl: newFuncDecl(s.rawCtx, C.Z3_get_datatype_sort_constructor_accessor(
+					s.rawCtx, s.rawSort, C.uint(i), C.uint(j))),
+			}
+		}
+		d.constructors[i] = ctor
+	}
+	return d
+}
+
+// Sort returns the sort of the datatype.
+func (d *Datatype) Sort() *Sort {
+	return d.sort
+}
+
+// Constructors returns the constructors of the datatype in declaration
+// order.
+func (d *Datatype) Constructors() []*DatatypeConstructor {
+	return d.constructors
+}
+
+// Constructor returns the constructor with the given name, or nil if the
+// datatype has no such constructor.
+func (d *Datatype) Constructor(name string) *DatatypeConstructor {
+	for _, ctor := range d.constructors {
+		if ctor.Name() == name {
+			return ctor
+		}
+	}
+	return nil
+}
+
+// Decompose splits a value of the datatype, such as the result of
+// Model.Eval, into the constructor that built it and its field values.
+// An error is returned if the value is not a constructor application.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (d *Datatype) Decompose(v *AST) (*DatatypeConstructor, []*AST, error) {
+	if C.Z3_get_ast_kind(v.rawCtx, v.rawAST) == C.Z3_APP_AST {
+		app := C.Z3_to_app(v.rawCtx, v.rawAST)
+		decl := C.Z3_get_app_decl(v.rawCtx, app)
+		checkError(v.rawCtx)
+		for _, ctor := range d.constructors {
+			if !bool(C.Z3_is_eq_func_decl(v.rawCtx, decl, ctor.decl.rawFuncDecl)) {
+				continue
+			}
+
+			fields := make([]*AST, len(ctor.accessors))
+			for i := range fields {
+				fields[i] = newAST(v.rawCtx, C.Z3_get_app_arg(v.rawCtx, app, C.uint(i)))
+			}
+			return ctor, fields, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("not a constructor application: %s", v.String())
+}
+
+// Name returns the name of the constructor.
+func (c *DatatypeConstructor) Name() string {
+	return c.decl.Name().String()
+}
+
+// Decl returns the function declaration of the constructor.
+func (c *DatatypeConstructor) Decl() *FuncDecl {
+	return c.decl
+}
+
+// Recognizer returns the function declaration of the recognizer, the
+// predicate used by Is.
+func (c *DatatypeConstructor) Recognizer() *FuncDecl {
+	return c.tester
+}
+
+// Apply creates a value of the datatype using this constructor. There
+// must be one argument per field.
+//
+// Maps to: Z3_mk_app
+func (c *DatatypeConstructor) Apply(args ...*AST) *AST {
+	return c.decl.Apply(args...)
+}
+
+// Is creates a predicate that holds if a was built with this constructor.
+//
+// Maps to: Z3_mk_app
+func (c *DatatypeConstructor) Is(a *AST) *AST {
+	return c.tester.Apply(a)
+}
+
+// Accessors returns the field accessors of the constructor in
+// declaration order.
+func (c *DatatypeConstructor) Accessors() []*DatatypeAccessor {
+	return c.accessors
+}
+
+// Name returns the name of the field.
+func (a *DatatypeAccessor) Name() string {
+	return a.decl.Name().String()
+}
+
+// Decl returns the function declaration of the accessor.
+func (a *DatatypeAccessor) Decl() *FuncDecl {
+	return a.decl
+}
+
+// Apply creates an AST node representing the field of the value v. The
+// result is unspecified if v was built with a different constructor.
+//
+// Maps to: Z3_mk_app
+func (a *DatatypeAccessor) Apply(v *AST) *AST {
+	return a.decl.Apply(v)
+}
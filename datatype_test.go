@@ -0,0 +1,135 @@
+package z3
+
+import (
+	"testing"
+)
+
+func TestEnumSort(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	state := ctx.EnumSort("State", "Idle", "Busy", "Done")
+	if n := len(state.Constructors()); n != 3 {
+		t.Fatalf("bad: %d", n)
+	}
+
+	x := ctx.Const(ctx.Symbol("x"), state.Sort())
+	idle := state.Constructor("Idle")
+	done := state.Constructor("Done")
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	s.Assert(idle.Is(x).Not())
+	s.Assert(x.Eq(done.Apply()).Not())
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+
+	ctor, fields, err := state.Decompose(m.Eval(x))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ctor.Name() != "Busy" || len(fields) != 0 {
+		t.Fatalf("bad: %s %v", ctor.Name(), fields)
+	}
+}
+
+func TestTupleSort(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	pair := ctx.TupleSort("Pair",
+		DatatypeField{Name: "first", Sort: intTyp},
+		DatatypeField{Name: "second", Sort: ctx.BoolSort()})
+
+	mk := pair.Constructor("Pair")
+	raw := mk.Apply(ctx.Int(1, intTyp), ctx.True())
+
+	actual := raw.String()
+	if actual != "(Pair 1 true)" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	first := mk.Accessors()[0]
+	if first.Name() != "first" {
+		t.Fatalf("bad: %s", first.Name())
+	}
+	if v := first.Apply(raw).Simplify().String(); v != "1" {
+		t.Fatalf("bad: %s", v)
+	}
+}
+
+func TestMutuallyRecursiveDatatypes(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+
+	// Tree = leaf(val: Int) | node(children: TreeList)
+	// TreeList = nil | cons(head: Tree, tail: TreeList)
+	tree := ctx.MkDatatypeDecl("Tree")
+	list := ctx.MkDatatypeDecl("TreeList")
+	tree.Constructor("leaf", DatatypeField{Name: "val", Sort: intTyp}).
+		Constructor("node", DatatypeField{Name: "children", Ref: list})
+	list.Constructor("nil").
+		Constructor("cons",
+			DatatypeField{Name: "head", Ref: tree},
+			DatatypeField{Name: "tail", Ref: list})
+
+	types := ctx.MkDatatypes(tree, list)
+	treeTyp, listTyp := types[0], types[1]
+
+	x := ctx.Const(ctx.Symbol("x"), treeTyp.Sort())
+	node := treeTyp.Constructor("node")
+	cons := listTyp.Constructor("cons")
+	leaf := treeTyp.Constructor("leaf")
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	// x is a node whose first child is leaf(7)
+	s.Assert(node.Is(x))
+	children := node.Accessors()[0].Apply(x)
+	s.Assert(cons.Is(children))
+	s.Assert(cons.Accessors()[0].Apply(children).Eq(leaf.Apply(ctx.Int(7, intTyp))))
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+
+	ctor, fields, err := treeTyp.Decompose(m.Eval(x))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ctor.Name() != "node" || len(fields) != 1 {
+		t.Fatalf("bad: %s %v", ctor.Name(), fields)
+	}
+
+	ctor, fields, err = listTyp.Decompose(fields[0])
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ctor.Name() != "cons" || fields[0].String() != "(leaf 7)" {
+		t.Fatalf("bad: %s %v", ctor.Name(), fields)
+	}
+
+	// Nothing to declare is not an error
+	if types := ctx.MkDatatypes(); types != nil {
+		t.Fatalf("bad: %v", types)
+	}
+}
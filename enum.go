@@ -22,4 +22,33 @@ func (b LBool) String() string {
 	} else {
 		panic("Unknown LBool")
 	}
-}
\ No newline at end of file
+}
+
+// RoundingMode is the IEEE-754 rounding mode applied by floating-point
+// operations. Use Context.RoundingMode to obtain the matching AST.
+type RoundingMode int
+
+const (
+	RoundNearestTiesToEven RoundingMode = iota
+	RoundNearestTiesToAway
+	RoundTowardPositive
+	RoundTowardNegative
+	RoundTowardZero
+)
+
+func (rm RoundingMode) String() string {
+	switch rm {
+	case RoundNearestTiesToEven:
+		return "RNE"
+	case RoundNearestTiesToAway:
+		return "RNA"
+	case RoundTowardPositive:
+		return "RTP"
+	case RoundTowardNegative:
+		return "RTN"
+	case RoundTowardZero:
+		return "RTZ"
+	default:
+		panic("Unknown RoundingMode")
+	}
+}
@@ -1,6 +1,15 @@
 package z3
 
-import "sync"
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+)
 
 // #include "go-z3.h"
 import "C"
@@ -10,26 +19,116 @@ type ErrorCode uint
 
 const (
 	ErrorCodeOk              ErrorCode = C.Z3_OK
-	ErrorCodeSortError                 = C.Z3_SORT_ERROR
-	ErrorCodeIOB                       = C.Z3_IOB
-	ErrorCodeInvalidArg                = C.Z3_INVALID_ARG
-	ErrorCodeParserError               = C.Z3_PARSER_ERROR
-	ErrorCodeNoParser                  = C.Z3_NO_PARSER
-	ErrorCodeInvalidPattern            = C.Z3_INVALID_PATTERN
-	ErrorCodeMemoutFail                = C.Z3_MEMOUT_FAIL
-	ErrorCodeFileAccessError           = C.Z3_FILE_ACCESS_ERROR
-	ErrorCodeInternalFatal             = C.Z3_INTERNAL_FATAL
-	ErrorCodeInvalidUsage              = C.Z3_INVALID_USAGE
-	ErrorCodeDecRefError               = C.Z3_DEC_REF_ERROR
-	ErrorCodeException                 = C.Z3_EXCEPTION
+	ErrorCodeSortError       ErrorCode = C.Z3_SORT_ERROR
+	ErrorCodeIOB             ErrorCode = C.Z3_IOB
+	ErrorCodeInvalidArg      ErrorCode = C.Z3_INVALID_ARG
+	ErrorCodeParserError     ErrorCode = C.Z3_PARSER_ERROR
+	ErrorCodeNoParser        ErrorCode = C.Z3_NO_PARSER
+	ErrorCodeInvalidPattern  ErrorCode = C.Z3_INVALID_PATTERN
+	ErrorCodeMemoutFail      ErrorCode = C.Z3_MEMOUT_FAIL
+	ErrorCodeFileAccessError ErrorCode = C.Z3_FILE_ACCESS_ERROR
+	ErrorCodeInternalFatal   ErrorCode = C.Z3_INTERNAL_FATAL
+	ErrorCodeInvalidUsage    ErrorCode = C.Z3_INVALID_USAGE
+	ErrorCodeDecRefError     ErrorCode = C.Z3_DEC_REF_ERROR
+	ErrorCodeException       ErrorCode = C.Z3_EXCEPTION
+)
+
+// Error implements error so that the codes can be used as targets of
+// errors.Is, which matches a *Z3Error with the same code.
+func (c ErrorCode) Error() string {
+	switch c {
+	case ErrorCodeOk:
+		return "ok"
+	case ErrorCodeSortError:
+		return "sort error"
+	case ErrorCodeIOB:
+		return "index out of bounds"
+	case ErrorCodeInvalidArg:
+		return "invalid argument"
+	case ErrorCodeParserError:
+		return "parser error"
+	case ErrorCodeNoParser:
+		return "no parser"
+	case ErrorCodeInvalidPattern:
+		return "invalid pattern"
+	case ErrorCodeMemoutFail:
+		return "out of memory"
+	case ErrorCodeFileAccessError:
+		return "file access error"
+	case ErrorCodeInternalFatal:
+		return "internal fatal error"
+	case ErrorCodeInvalidUsage:
+		return "invalid usage"
+	case ErrorCodeDecRefError:
+		return "invalid reference count"
+	case ErrorCodeException:
+		return "exception"
+	default:
+		return fmt.Sprintf("error code %d", uint(c))
+	}
+}
+
+// Z3Error is an error reported by Z3 for a call made by go-z3. Note that
+// Z3 reports many errors, such as sort mismatches while creating an AST,
+// as ErrorCodeException with a descriptive Msg.
+type Z3Error struct {
+	// Code is the Z3 error code.
+	Code ErrorCode
+
+	// Msg is the message of Z3 describing the error.
+	Msg string
+
+	// Op is the go-z3 function that made the failing call, such as
+	// "AST.Add". It is empty if it couldn't be determined.
+	Op string
+}
+
+func (e *Z3Error) Error() string {
+	if e.Op == "" {
+		return "z3: " + e.Msg
+	}
+	return "z3: " + e.Op + ": " + e.Msg
+}
+
+// Is reports whether target is the ErrorCode of the error.
+func (e *Z3Error) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && code == e.Code
+}
+
+// ErrorMode selects how errors of the Z3 calls made through a Context are
+// reported. It is set with SetErrorMode.
+type ErrorMode int
+
+const (
+	// ErrorModeHandler reports errors to the handler registered with
+	// SetErrorHandler, after which the failing call returns an unusable
+	// value. Without a handler, Z3 prints the error and exits the process.
+	// This is the default.
+	ErrorModeHandler ErrorMode = iota
+
+	// ErrorModePanic makes the go-z3 functions check for an error after
+	// calling Z3 and panic with a *Z3Error, so that a failing call never
+	// returns an unusable value or is silently ignored. Use Context.Try to turn the
+	// panic into a returned error. The handler registered with
+	// SetErrorHandler, if any, is still called first.
+	ErrorModePanic
+
+	// errorModeReturn is used by the go-z3 functions that return the
+	// errors of their Z3 calls, see returnErrors.
+	errorModeReturn ErrorMode = -1
 )
 
 // ErrorHandler is the callback that is invoked when an error occurs in
 // Z3 and is registered by SetErrorHandler.
 type ErrorHandler func(*Context, ErrorCode)
 
-// These unexported vars are used to keep track of our error handlers.
+// These unexported vars are used to keep track of our error handlers and
+// modes. errorModePanicCount is the number of contexts in ErrorModePanic,
+// which saves checking for errors while there are none.
 var errorHandlerMap = map[C.Z3_context]ErrorHandler{}
+var errorModeMap = map[C.Z3_context]ErrorMode{}
+var errorModePanicCount int32
 var errorHandlerMapLock sync.RWMutex
 
 // SetErrorHandler registers the error handler. This handler is invoked
@@ -42,6 +141,78 @@ func (c *Context) SetErrorHandler(f ErrorHandler) {
 	errorHandlerMap[c.rawCtx] = f
 }
 
+// SetErrorMode sets how the errors of Z3 calls made through the context
+// are reported. See ErrorMode.
+func (c *Context) SetErrorMode(mode ErrorMode) {
+	C.Z3_set_error_handler(c.rawCtx, C._go_z3_error_handler())
+
+	errorHandlerMapLock.Lock()
+	defer errorHandlerMapLock.Unlock()
+	c.setErrorModeLocked(mode)
+}
+
+// setErrorModeLocked sets the mode of the context. The caller must hold
+// errorHandlerMapLock.
+func (c *Context) setErrorModeLocked(mode ErrorMode) {
+	if errorModeMap[c.rawCtx] == ErrorModePanic {
+		atomic.AddInt32(&errorModePanicCount, -1)
+	}
+	if mode == ErrorModePanic {
+		atomic.AddInt32(&errorModePanicCount, 1)
+	}
+
+	if mode == ErrorModeHandler {
+		delete(errorModeMap, c.rawCtx)
+	} else {
+		errorModeMap[c.rawCtx] = mode
+	}
+}
+
+// ErrorMode returns how the errors of Z3 calls made through the context
+// are reported.
+func (c *Context) ErrorMode() ErrorMode {
+	errorHandlerMapLock.RLock()
+	defer errorHandlerMapLock.RUnlock()
+	return errorModeMap[c.rawCtx]
+}
+
+// Try calls f with the context in ErrorModePanic and returns the *Z3Error
+// that f panics with, or nil if f returns normally. Other panics are
+// propagated. The previous error mode is restored afterwards.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (c *Context) Try(f func()) (err error) {
+	prev := c.ErrorMode()
+	c.SetErrorMode(ErrorModePanic)
+	defer c.SetErrorMode(prev)
+
+	defer func() {
+		if r := recover(); r != nil {
+			z3err, ok := r.(*Z3Error)
+			if !ok {
+				panic(r)
+			}
+			err = z3err
+		}
+	}()
+
+	f()
+	return nil
+}
+
+// returnErrors calls f with the errors of the context ignored by both the
+// error handler and ErrorModePanic, so that f can check the error code of
+// its Z3 calls and return the errors instead. The previous error mode is
+// restored afterwards.
+func (c *Context) returnErrors(f func()) {
+	prev := c.ErrorMode()
+	c.SetErrorMode(errorModeReturn)
+	defer c.SetErrorMode(prev)
+
+	f()
+}
+
 // Error returns the error message for the given error code.
 // This code can be retrieved via the error handler callback.
 //
@@ -58,12 +229,89 @@ func goZ3ErrorHandler(raw C.Z3_context, code C.Z3_error_code) {
 	errorHandlerMapLock.RLock()
 	defer errorHandlerMapLock.RUnlock()
 
-	// Look up the error handler for this context
+	// An interrupted tactic raises an exception, which is expected while
+	// interruptible cancels it and is reported as a CanceledError instead.
+	interruptedMapLock.RLock()
+	interrupted := interruptedMap[raw]
+	interruptedMapLock.RUnlock()
+	if interrupted && ErrorCode(code) == ErrorCodeException {
+		return
+	}
+
+	// The function that made the failing call returns the error.
+	if errorModeMap[raw] == errorModeReturn {
+		return
+	}
+
+	// Look up the error handler for this context. Without one, behave
+	// like the default Z3 error handler, which this handler replaced.
 	f, ok := errorHandlerMap[raw]
 	if !ok {
-		return
+		// In ErrorModePanic the function that made the failing call
+		// reports the error.
+		if errorModeMap[raw] == ErrorModePanic {
+			return
+		}
+
+		fmt.Fprintf(os.Stderr, "Error: %s\n", C.GoString(C.Z3_get_error_msg(raw, code)))
+		os.Exit(1)
 	}
 
 	// Call it!
 	f(&Context{rawCtx: raw}, ErrorCode(code))
 }
+
+// checkError panics with a *Z3Error if the context is in ErrorModePanic
+// and the last Z3 call made through it failed.
+func checkError(rawCtx C.Z3_context) {
+	if atomic.LoadInt32(&errorModePanicCount) == 0 {
+		return
+	}
+
+	errorHandlerMapLock.RLock()
+	mode := errorModeMap[rawCtx]
+	errorHandlerMapLock.RUnlock()
+	if mode != ErrorModePanic {
+		return
+	}
+
+	if code := C.Z3_get_error_code(rawCtx); code != C.Z3_OK {
+		panic(newZ3Error(rawCtx, code))
+	}
+}
+
+// newZ3Error creates the error for the given code, which must be the
+// current error code of the context.
+func newZ3Error(rawCtx C.Z3_context, code C.Z3_error_code) *Z3Error {
+	return &Z3Error{
+		Code: ErrorCode(code),
+		Msg:  C.GoString(C.Z3_get_error_msg(rawCtx, code)),
+		Op:   callerOp(),
+	}
+}
+
+// pkgPrefix is the prefix of the names of the functions of this package,
+// as reported by the runtime.
+var pkgPrefix = reflect.TypeOf(Z3Error{}).PkgPath() + "."
+
+// callerOp returns the name of the innermost exported function of this
+// package on the call stack, such as "AST.Add".
+func callerOp() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, pkgPrefix) {
+			name := strings.TrimPrefix(frame.Function, pkgPrefix)
+			name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+
+			method := name[strings.LastIndex(name, ".")+1:]
+			if method != "" && unicode.IsUpper(rune(method[0])) {
+				return name
+			}
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package z3
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -32,3 +33,211 @@ func TestContextErrorHandler(t *testing.T) {
 		t.Fatalf("bad: %s", msg)
 	}
 }
+
+func TestContextErrorModePanic(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	ctx.SetErrorMode(ErrorModePanic)
+	if ctx.ErrorMode() != ErrorModePanic {
+		t.Fatalf("bad: %v", ctx.ErrorMode())
+	}
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.BoolSort())
+	y := ctx.Const(ctx.Symbol("y"), ctx.BoolSort())
+
+	func() {
+		defer func() {
+			err, ok := recover().(*Z3Error)
+			if !ok {
+				t.Fatal("should panic with a *Z3Error")
+			}
+			if err.Code != ErrorCodeException || err.Op != "AST.Ge" {
+				t.Fatalf("bad: %#v", err)
+			}
+			if !strings.Contains(err.Msg, "Sort mismatch") {
+				t.Fatalf("bad: %s", err.Msg)
+			}
+		}()
+
+		// This won't work because x and y aren't ints
+		x.Ge(y)
+	}()
+
+	// Valid calls don't panic
+	if s := x.And(y).String(); s != "(and x y)" {
+		t.Fatalf("bad: %s", s)
+	}
+}
+
+func TestContextTry(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	b := ctx.Const(ctx.Symbol("b"), ctx.BoolSort())
+
+	var sum *AST
+	err := ctx.Try(func() {
+		sum = x.Add(b)
+	})
+	if err == nil || sum != nil {
+		t.Fatalf("bad: %v %v", err, sum)
+	}
+	if !errors.Is(err, ErrorCodeException) {
+		t.Fatalf("err: %s", err)
+	}
+	if errors.Is(err, ErrorCodeInvalidArg) {
+		t.Fatalf("err: %s", err)
+	}
+	var z3err *Z3Error
+	if !errors.As(err, &z3err) || z3err.Op != "AST.Add" {
+		t.Fatalf("err: %#v", err)
+	}
+
+	// The previous mode is restored
+	if ctx.ErrorMode() != ErrorModeHandler {
+		t.Fatalf("bad: %v", ctx.ErrorMode())
+	}
+
+	err = ctx.Try(func() {
+		sum = x.Add(x)
+	})
+	if err != nil || sum == nil {
+		t.Fatalf("bad: %v %v", err, sum)
+	}
+
+	// Other panics are propagated
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Fatalf("bad: %v", r)
+			}
+		}()
+		ctx.Try(func() {
+			panic("boom")
+		})
+	}()
+}
+
+func TestContextTryConstructors(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	// Z3 returns no tactic, which must not be referenced
+	var tactic *Tactic
+	err := ctx.Try(func() {
+		tactic = ctx.MkTactic("no-such-tactic")
+	})
+	var z3err *Z3Error
+	if !errors.As(err, &z3err) || z3err.Op != "Context.MkTactic" || tactic != nil {
+		t.Fatalf("bad: %#v %v", err, tactic)
+	}
+}
+
+func TestContextTryAssert(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	// Asserting an int is an error, not a dropped assertion
+	err := ctx.Try(func() {
+		s.Assert(x)
+	})
+	var z3err *Z3Error
+	if !errors.As(err, &z3err) || z3err.Op != "Solver.Assert" {
+		t.Fatalf("bad: %#v", err)
+	}
+
+	err = ctx.Try(func() {
+		s.AssertAndTrack(x, ctx.Const(ctx.Symbol("l"), ctx.BoolSort()))
+	})
+	if !errors.As(err, &z3err) || z3err.Op != "Solver.AssertAndTrack" {
+		t.Fatalf("bad: %#v", err)
+	}
+
+	g := ctx.MkGoal(true, false, false)
+	defer g.Close()
+	err = ctx.Try(func() {
+		g.Assert(x)
+	})
+	if !errors.As(err, &z3err) || z3err.Op != "Goal.Assert" {
+		t.Fatalf("bad: %#v", err)
+	}
+
+	o := ctx.MkOptimize()
+	defer o.Close()
+	err = ctx.Try(func() {
+		o.Add(x)
+	})
+	if !errors.As(err, &z3err) || z3err.Op != "Optimize.Add" {
+		t.Fatalf("bad: %#v", err)
+	}
+
+	// Popping more scopes than were pushed is an error too
+	err = ctx.Try(func() {
+		s.Pop(1)
+	})
+	if !errors.As(err, &z3err) || z3err.Op != "Solver.Pop" {
+		t.Fatalf("bad: %#v", err)
+	}
+
+	err = ctx.Try(func() {
+		if result := s.Check(); result != True {
+			t.Fatalf("bad: %s", result)
+		}
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestContextTryReaders(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	// Readers report errors too, rather than returning a zero value
+	var size uint
+	err := ctx.Try(func() {
+		size = ctx.IntSort().BVSize()
+	})
+	var z3err *Z3Error
+	if !errors.As(err, &z3err) || z3err.Op != "Sort.BVSize" {
+		t.Fatalf("bad: %#v", err)
+	}
+	if size != 0 {
+		t.Fatalf("bad: %d", size)
+	}
+
+	err = ctx.Try(func() {
+		ctx.IntSort().FPEBits()
+	})
+	if !errors.As(err, &z3err) || z3err.Op != "Sort.FPEBits" {
+		t.Fatalf("bad: %#v", err)
+	}
+
+	p := ctx.MkParams()
+	defer p.Close()
+	err = ctx.Try(func() {
+		if v := ctx.BitVecSort(8).BVSize(); v != 8 {
+			t.Fatalf("bad: %d", v)
+		}
+		p.SetUint("timeout", 1000)
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
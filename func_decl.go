@@ -0,0 +1,172 @@
+package z3
+
+import (
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "go-z3.h"
+import "C"
+
+// FuncDecl represents a function declaration in Z3. Uninterpreted
+// functions are declared with Context.FuncDecl and applied to arguments
+// with Apply.
+//
+// FuncDecl memory management is automatic, like it is for AST.
+type FuncDecl struct {
+	rawCtx      C.Z3_context
+	rawFuncDecl C.Z3_func_decl
+}
+
+// newFuncDecl wraps a declaration returned by Z3 and takes a reference to
+// it, like newAST does for ASTs.
+func newFuncDecl(rawCtx C.Z3_context, rawFuncDecl C.Z3_func_decl) *FuncDecl {
+	checkError(rawCtx)
+	f := &FuncDecl{
+		rawCtx:      rawCtx,
+		rawFuncDecl: rawFuncDecl,
+	}
+	if rawFuncDecl != nil {
+		raw := C.Z3_func_decl_to_ast(rawCtx, rawFuncDecl)
+		C.Z3_inc_ref(rawCtx, raw)
+		track(rawCtx, f, func() {
+			C.Z3_dec_ref(rawCtx, raw)
+		})
+	}
+	return f
+}
+
+// FuncDecl declares an uninterpreted function with the given argument
+// types and result type.
+//
+// Maps to: Z3_mk_func_decl
+func (c *Context) FuncDecl(name *Symbol, domain []*Sort, rng *Sort) *FuncDecl {
+	raws := make([]C.Z3_sort, len(domain))
+	for i, s := range domain {
+		raws[i] = s.rawSort
+	}
+
+	var ptr *C.Z3_sort
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+
+	return newFuncDecl(c.rawCtx, C.Z3_mk_func_decl(
+		c.rawCtx, name.rawSymbol, C.uint(len(raws)), ptr, rng.rawSort))
+}
+
+// FreshFuncDecl declares an uninterpreted function whose name starts with
+// prefix and is guaranteed not to clash with any other declaration.
+//
+// Maps to: Z3_mk_fresh_func_decl
+func (c *Context) FreshFuncDecl(prefix string, domain []*Sort, rng *Sort) *FuncDecl {
+	raws := make([]C.Z3_sort, len(domain))
+	for i, s := range domain {
+		raws[i] = s.rawSort
+	}
+
+	var ptr *C.Z3_sort
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+
+	ns := C.CString(prefix)
+	defer C.free(unsafe.Pointer(ns))
+
+	return newFuncDecl(c.rawCtx, C.Z3_mk_fresh_func_decl(
+		c.rawCtx, ns, C.uint(len(raws)), ptr, rng.rawSort))
+}
+
+// FreshConst declares a variable whose name starts with prefix and is
+// guaranteed not to clash with any other declaration.
+//
+// Maps to: Z3_mk_fresh_const
+func (c *Context) FreshConst(prefix string, typ *Sort) *AST {
+	ns := C.CString(prefix)
+	defer C.free(unsafe.Pointer(ns))
+
+	return newAST(c.rawCtx, C.Z3_mk_fresh_const(c.rawCtx, ns, typ.rawSort))
+}
+
+// String returns a human-friendly string version of the declaration.
+//
+// Maps to: Z3_func_decl_to_string
+func (f *FuncDecl) String() string {
+	result := C.GoString(C.Z3_func_decl_to_string(f.rawCtx, f.rawFuncDecl))
+	checkError(f.rawCtx)
+	return result
+}
+
+// Name returns the name of the declaration.
+//
+// Maps to: Z3_get_decl_name
+func (f *FuncDecl) Name() *Symbol {
+	rawSymbol := C.Z3_get_decl_name(f.rawCtx, f.rawFuncDecl)
+	checkError(f.rawCtx)
+	return &Symbol{
+		rawCtx:    f.rawCtx,
+		rawSymbol: rawSymbol,
+	}
+}
+
+// Arity returns the number of arguments of the declaration.
+//
+// Maps to: Z3_get_arity
+func (f *FuncDecl) Arity() int {
+	result := int(C.Z3_get_arity(f.rawCtx, f.rawFuncDecl))
+	checkError(f.rawCtx)
+	return result
+}
+
+// Domain returns the type of the i-th argument. i must be less than
+// Arity.
+//
+// Maps to: Z3_get_domain
+func (f *FuncDecl) Domain(i int) *Sort {
+	return newSort(f.rawCtx, C.Z3_get_domain(f.rawCtx, f.rawFuncDecl, C.uint(i)))
+}
+
+// Range returns the result type of the declaration.
+//
+// Maps to: Z3_get_range
+func (f *FuncDecl) Range() *Sort {
+	return newSort(f.rawCtx, C.Z3_get_range(f.rawCtx, f.rawFuncDecl))
+}
+
+// Apply creates an AST node representing the function applied to args.
+// There must be one argument per element of the domain.
+//
+// Maps to: Z3_mk_app
+func (f *FuncDecl) Apply(args ...*AST) *AST {
+	return newAST(f.rawCtx, mkApp(f.rawCtx, f.rawFuncDecl, args))
+}
+
+// AST returns the declaration as an AST, for use with the APIs that
+// represent declarations that way, such as AST.DeclName.
+//
+// Maps to: Z3_func_decl_to_ast
+func (f *FuncDecl) AST() *AST {
+	return newAST(f.rawCtx, C.Z3_func_decl_to_ast(f.rawCtx, f.rawFuncDecl))
+}
+
+// asFuncDecl returns a declaration represented as an AST, whose Kind is
+// ASTKindFuncDecl, as a FuncDecl.
+func (a *AST) asFuncDecl() *FuncDecl {
+	return newFuncDecl(a.rawCtx, C.Z3_to_func_decl(a.rawCtx, a.rawAST))
+}
+
+// mkApp applies a function declaration to the given arguments.
+func mkApp(rawCtx C.Z3_context, decl C.Z3_func_decl, args []*AST) C.Z3_ast {
+	raws := make([]C.Z3_ast, len(args))
+	for i, arg := range args {
+		raws[i] = arg.rawAST
+	}
+
+	var ptr *C.Z3_ast
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+	result := C.Z3_mk_app(rawCtx, decl, C.uint(len(raws)), ptr)
+	checkError(rawCtx)
+	return result
+}
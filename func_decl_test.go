@@ -0,0 +1,108 @@
+package z3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFuncDecl(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{intTyp, intTyp}, ctx.BoolSort())
+
+	if f.Name().String() != "f" {
+		t.Fatalf("bad: %s", f.Name())
+	}
+	if f.Arity() != 2 {
+		t.Fatalf("bad: %d", f.Arity())
+	}
+	if f.Domain(1).String() != "Int" || f.Range().String() != "Bool" {
+		t.Fatalf("bad: %s %s", f.Domain(1), f.Range())
+	}
+	if v := f.String(); v != "(declare-fun f (Int Int) Bool)" {
+		t.Fatalf("bad: %s", v)
+	}
+
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	raw := f.Apply(x, ctx.Int(1, intTyp))
+
+	actual := raw.String()
+	if actual != "(f x 1)" {
+		t.Fatalf("bad:\n%s", actual)
+	}
+	if v := f.AST().DeclName().String(); v != "f" {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := raw.DeclName().String(); v != "f" {
+		t.Fatalf("bad: %s", v)
+	}
+}
+
+func TestFuncDeclCongruence(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{intTyp}, intTyp)
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	y := ctx.Const(ctx.Symbol("y"), intTyp)
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	// x = y and f(x) != f(y) is unsatisfiable
+	s.Assert(x.Eq(y))
+	s.Assert(f.Apply(x).Eq(f.Apply(y)).Not())
+
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+}
+
+func TestFreshFuncDecl(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	f := ctx.FreshFuncDecl("f", []*Sort{intTyp}, intTyp)
+	g := ctx.FreshFuncDecl("f", []*Sort{intTyp}, intTyp)
+	if f.Name().String() == g.Name().String() {
+		t.Fatalf("bad: %s %s", f.Name(), g.Name())
+	}
+
+	x := ctx.FreshConst("x", intTyp)
+	if !strings.HasPrefix(x.String(), "x") {
+		t.Fatalf("bad: %s", x)
+	}
+}
+
+func TestMap(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{intTyp, intTyp}, intTyp)
+	a := ctx.Const(ctx.Symbol("a"), ctx.ArraySort(intTyp, intTyp))
+	b := ctx.Const(ctx.Symbol("b"), ctx.ArraySort(intTyp, intTyp))
+	i := ctx.Const(ctx.Symbol("i"), intTyp)
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	// map(f, a, b)[i] == f(a[i], b[i])
+	s.Assert(ctx.Map(f, a, b).Select(i).Eq(f.Apply(a.Select(i), b.Select(i))).Not())
+
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+}
@@ -0,0 +1,221 @@
+package z3
+
+import (
+	"strings"
+)
+
+// #include "go-z3.h"
+import "C"
+
+// FuncInterp is the interpretation of a function in a model: a finite
+// table of argument tuples and their values, together with the value of
+// the function for all other arguments (the else value).
+//
+// Memory management for this is based on reference counting. The
+// interpretation is freed once it is garbage collected, or right away by
+// calling Close when you're done.
+type FuncInterp struct {
+	rawCtx        C.Z3_context
+	rawFuncInterp C.Z3_func_interp
+}
+
+// newFuncInterp wraps a function interpretation returned by Z3 and takes a reference to it,
+// which is released by Close or once the FuncInterp is garbage collected.
+func newFuncInterp(rawCtx C.Z3_context, rawFuncInterp C.Z3_func_interp) *FuncInterp {
+	checkError(rawCtx)
+	fi := &FuncInterp{
+		rawCtx:        rawCtx,
+		rawFuncInterp: rawFuncInterp,
+	}
+	if rawFuncInterp != nil {
+		C.Z3_func_interp_inc_ref(rawCtx, rawFuncInterp)
+		track(rawCtx, fi, func() {
+			C.Z3_func_interp_dec_ref(rawCtx, rawFuncInterp)
+		})
+	}
+	return fi
+}
+
+// FuncEntry is a single row of a FuncInterp: the function maps Args to
+// Value.
+type FuncEntry struct {
+	Args  []*AST
+	Value *AST
+}
+
+// NumFuncs returns the number of function interpretations in the model.
+//
+// Maps: Z3_model_get_num_funcs
+func (m *Model) NumFuncs() uint {
+	result := uint(C.Z3_model_get_num_funcs(m.rawCtx, m.rawModel))
+	checkError(m.rawCtx)
+	return result
+}
+
+// FuncDecl returns the declaration of the function interpreted at the
+// given index. idx must be less than NumFuncs.
+//
+// Maps: Z3_model_get_func_decl
+func (m *Model) FuncDecl(idx uint) *FuncDecl {
+	return newFuncDecl(m.rawCtx, C.Z3_model_get_func_decl(m.rawCtx, m.rawModel, C.uint(idx)))
+}
+
+// FuncInterp returns the interpretation of f in the model, or nil if the
+// model does not assign f.
+//
+// Maps: Z3_model_get_func_interp
+func (m *Model) FuncInterp(f *FuncDecl) *FuncInterp {
+	raw := C.Z3_model_get_func_interp(m.rawCtx, m.rawModel, f.rawFuncDecl)
+	if raw == nil {
+		return nil
+	}
+
+	return newFuncInterp(m.rawCtx, raw)
+}
+
+// FuncInterps returns all the function interpretations of the model. The
+// key of the map will be the String value of the function's name.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (m *Model) FuncInterps() map[string]*FuncInterp {
+	result := make(map[string]*FuncInterp)
+	for i := uint(0); i < m.NumFuncs(); i++ {
+		f := m.FuncDecl(i)
+		result[f.Name().String()] = m.FuncInterp(f)
+	}
+	return result
+}
+
+// Close decreases the reference count for this interpretation.
+func (fi *FuncInterp) Close() error {
+	untrack(fi.rawCtx, fi)
+	return nil
+}
+
+// Arity returns the number of arguments of the interpreted function.
+//
+// Maps: Z3_func_interp_get_arity
+func (fi *FuncInterp) Arity() uint {
+	result := uint(C.Z3_func_interp_get_arity(fi.rawCtx, fi.rawFuncInterp))
+	checkError(fi.rawCtx)
+	return result
+}
+
+// NumEntries returns the number of rows in the table of the
+// interpretation.
+//
+// Maps: Z3_func_interp_get_num_entries
+func (fi *FuncInterp) NumEntries() uint {
+	result := uint(C.Z3_func_interp_get_num_entries(fi.rawCtx, fi.rawFuncInterp))
+	checkError(fi.rawCtx)
+	return result
+}
+
+// Entry returns a row of the table of the interpretation. idx must be less
+// than NumEntries.
+//
+// Maps: Z3_func_interp_get_entry
+func (fi *FuncInterp) Entry(idx uint) FuncEntry {
+	raw := C.Z3_func_interp_get_entry(fi.rawCtx, fi.rawFuncInterp, C.uint(idx))
+	C.Z3_func_entry_inc_ref(fi.rawCtx, raw)
+	defer C.Z3_func_entry_dec_ref(fi.rawCtx, raw)
+
+	n := uint(C.Z3_func_entry_get_num_args(fi.rawCtx, raw))
+	checkError(fi.rawCtx)
+	e := FuncEntry{
+		Args:  make([]*AST, n),
+		Value: newAST(fi.rawCtx, C.Z3_func_entry_get_value(fi.rawCtx, raw)),
+	}
+	for i := uint(0); i < n; i++ {
+		e.Args[i] = newAST(fi.rawCtx, C.Z3_func_entry_get_arg(fi.rawCtx, raw, C.uint(i)))
+	}
+	return e
+}
+
+// Entries returns all the rows of the table of the interpretation.
+func (fi *FuncInterp) Entries() []FuncEntry {
+	result := make([]FuncEntry, fi.NumEntries())
+	for i := range result {
+		result[i] = fi.Entry(uint(i))
+	}
+	return result
+}
+
+// Else returns the value of the function for the arguments not listed in
+// the table. It may refer to the arguments as bound variables, where
+// variable i is the i-th argument. Else returns nil if there is no such
+// value.
+//
+// Maps: Z3_func_interp_get_else
+func (fi *FuncInterp) Else() *AST {
+	raw := C.Z3_func_interp_get_else(fi.rawCtx, fi.rawFuncInterp)
+	if raw == nil {
+		return nil
+	}
+
+	return newAST(fi.rawCtx, raw)
+}
+
+// Evaluator returns a Go function computing the value of the interpreted
+// function for the given arguments, which must be values (such as
+// numerals) rather than arbitrary terms. The function returns nil unless
+// there are as many arguments as the arity. The evaluator keeps working
+// after the FuncInterp has been closed.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (fi *FuncInterp) Evaluator() func(args ...*AST) *AST {
+	rawCtx := fi.rawCtx
+	arity := int(fi.Arity())
+	entries := fi.Entries()
+	els := fi.Else()
+
+	return func(args ...*AST) *AST {
+		if len(args) != arity {
+			return nil
+		}
+
+	Entries:
+		for _, e := range entries {
+			for i, arg := range e.Args {
+				if !bool(C.Z3_is_eq_ast(rawCtx, arg.rawAST, args[i].rawAST)) {
+					continue Entries
+				}
+			}
+			return e.Value
+		}
+
+		if els == nil {
+			return nil
+		}
+
+		// Instantiate the bound variables of the else value with the
+		// arguments and reduce the result to a value.
+		return els.SubstituteVars(args).Simplify()
+	}
+}
+
+// String returns a human-friendly string version of the interpretation,
+// one row per line followed by the else value.
+func (fi *FuncInterp) String() string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, e := range fi.Entries() {
+		b.WriteString("  ")
+		for _, arg := range e.Args {
+			b.WriteString(arg.String())
+			b.WriteString(" ")
+		}
+		b.WriteString("-> ")
+		b.WriteString(e.Value.String())
+		b.WriteString("\n")
+	}
+	if els := fi.Else(); els != nil {
+		b.WriteString("  else -> ")
+		b.WriteString(els.String())
+		b.WriteString("\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package z3
+
+import (
+	"testing"
+)
+
+func TestModelFuncInterp(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{intTyp}, intTyp)
+	one := ctx.Int(1, intTyp)
+	two := ctx.Int(2, intTyp)
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	s.Assert(f.Apply(one).Eq(ctx.Int(10, intTyp)))
+	s.Assert(f.Apply(two).Eq(ctx.Int(20, intTyp)))
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+
+	if m.NumFuncs() != 1 {
+		t.Fatalf("bad: %d", m.NumFuncs())
+	}
+	if v := m.FuncDecl(0).Name().String(); v != "f" {
+		t.Fatalf("bad: %s", v)
+	}
+
+	fi := m.FuncInterps()["f"]
+	if fi == nil {
+		t.Fatal("should have an interpretation for f")
+	}
+	t.Logf("\nf:\n%s", fi)
+
+	if fi.Arity() != 1 {
+		t.Fatalf("bad: %d", fi.Arity())
+	}
+	if fi.Else() == nil {
+		t.Fatal("should have an else value")
+	}
+
+	eval := fi.Evaluator()
+	fi.Close()
+
+	if v := eval(one).String(); v != "10" {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := eval(two).String(); v != "20" {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := eval(ctx.Int(3, intTyp)); v == nil {
+		t.Fatal("should evaluate to the else value")
+	}
+}
+
+func TestFuncInterpEntries(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	g := ctx.FuncDecl(ctx.Symbol("g"), []*Sort{intTyp, intTyp}, ctx.BoolSort())
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	s.Assert(g.Apply(ctx.Int(1, intTyp), ctx.Int(2, intTyp)))
+	s.Assert(g.Apply(ctx.Int(2, intTyp), ctx.Int(1, intTyp)).Not())
+
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+
+	m := s.Model()
+	defer m.Close()
+
+	fi := m.FuncInterp(g)
+	if fi == nil {
+		t.Fatal("should have an interpretation for g")
+	}
+	defer fi.Close()
+
+	eval := fi.Evaluator()
+	if v := eval(ctx.Int(1, intTyp), ctx.Int(2, intTyp)).String(); v != "true" {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := eval(ctx.Int(2, intTyp), ctx.Int(1, intTyp)).String(); v != "false" {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := eval(ctx.Int(1, intTyp)); v != nil {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := eval(ctx.Int(1, intTyp), ctx.Int(2, intTyp), ctx.Int(3, intTyp)); v != nil {
+		t.Fatalf("bad: %s", v)
+	}
+	for _, e := range fi.Entries() {
+		if len(e.Args) != 2 {
+			t.Fatalf("bad: %v", e.Args)
+		}
+	}
+}
@@ -8,31 +8,46 @@ type Goal struct {
 	rawGoal C.Z3_goal
 }
 
-// Create a new goal
-func (c *Context) MkGoal(models, unsat_cores, proofs bool) *Goal {
-	rawGoal := C.Z3_mk_goal(c.rawCtx, C.bool(models), C.bool(unsat_cores), C.bool(proofs))
-	C.Z3_goal_inc_ref(c.rawCtx, rawGoal)
-	return &Goal{
-		rawCtx: c.rawCtx,
+// newGoal wraps a goal returned by Z3 and takes a reference to it,
+// which is released by Close or once the Goal is garbage collected.
+func newGoal(rawCtx C.Z3_context, rawGoal C.Z3_goal) *Goal {
+	checkError(rawCtx)
+	g := &Goal{
+		rawCtx:  rawCtx,
 		rawGoal: rawGoal,
 	}
+	if rawGoal != nil {
+		C.Z3_goal_inc_ref(rawCtx, rawGoal)
+		track(rawCtx, g, func() {
+			C.Z3_goal_dec_ref(rawCtx, rawGoal)
+		})
+	}
+	return g
+}
+
+// Create a new goal
+func (c *Context) MkGoal(models, unsat_cores, proofs bool) *Goal {
+	return newGoal(c.rawCtx, C.Z3_mk_goal(c.rawCtx, C.bool(models), C.bool(unsat_cores), C.bool(proofs)))
 }
 
 // String returns a human-friendly string version of the goal.
 func (t *Goal) String() string {
-	return C.GoString(C.Z3_goal_to_string(t.rawCtx, t.rawGoal))
+	result := C.GoString(C.Z3_goal_to_string(t.rawCtx, t.rawGoal))
+	checkError(t.rawCtx)
+	return result
 }
 
 // Z3_goal_assert
 func (g *Goal) Assert(a *AST) {
 	C.Z3_goal_assert(g.rawCtx, g.rawGoal, a.rawAST)
+	checkError(g.rawCtx)
 }
 
 // Close decreases the reference count for this goal. If nothing else
 // has manually increased the reference count, this will free the memory
 // associated with it.
 func (t *Goal) Close() error {
-	C.Z3_goal_dec_ref(t.rawCtx, t.rawGoal)
+	untrack(t.rawCtx, t)
 	return nil
 }
 
@@ -41,15 +56,14 @@ func (t *Goal) Close() error {
 // Z3_goal_formula
 func (a *Goal) GetFormula(i int) *AST {
 	rawAST := C.Z3_goal_formula(a.rawCtx, a.rawGoal, C.uint(i))
-	return &AST{
-		rawCtx: a.rawCtx,
-		rawAST: rawAST,
-	}
+	return newAST(a.rawCtx, rawAST)
 }
 
 // Return the number of formulas in the given goal.
 //
 // Maps to: Z3_goal_size
 func (g *Goal) GetGoalSize() int {
-	return int(C.Z3_goal_size(g.rawCtx, g.rawGoal))
+	result := int(C.Z3_goal_size(g.rawCtx, g.rawGoal))
+	checkError(g.rawCtx)
+	return result
 }
\ No newline at end of file
@@ -0,0 +1,110 @@
+package z3
+
+import (
+	"context"
+	"sync"
+)
+
+// #include "go-z3.h"
+import "C"
+
+// CanceledError is returned by the context-aware variants of the blocking
+// calls, such as Solver.CheckContext, when the call was interrupted because
+// its context.Context was canceled or its deadline passed.
+//
+// It wraps the error of the context.Context, so errors.Is(err,
+// context.Canceled) and errors.Is(err, context.DeadlineExceeded) work.
+type CanceledError struct {
+	Err error
+}
+
+func (e *CanceledError) Error() string {
+	return "z3: interrupted: " + e.Err.Error()
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// These unexported vars track the contexts that are being interrupted by
+// interruptible, so that the error handler can ignore the resulting
+// cancellation exception.
+var interruptedMap = map[C.Z3_context]bool{}
+var interruptedMapLock sync.RWMutex
+
+// Interrupt stops the solver, optimize or tactic currently running in the
+// context. It is safe to call from another goroutine. An interrupted check
+// returns Undef. Tactics applied afterwards keep failing until the next
+// solver check; the CheckContext and ApplyContext methods take care of this.
+//
+// Maps to: Z3_interrupt
+func (c *Context) Interrupt() {
+	C.Z3_interrupt(c.rawCtx)
+}
+
+// interruptible runs f and interrupts the Z3 context when ctx is done
+// before f returns. f reports whether it produced a conclusive result. The
+// returned error is a CanceledError if ctx was done before f started, or if
+// f was interrupted and produced no conclusive result.
+func interruptible(ctx context.Context, rawCtx C.Z3_context, f func() bool) error {
+	if err := ctx.Err(); err != nil {
+		return &CanceledError{Err: err}
+	}
+
+	done := ctx.Done()
+	if done == nil {
+		f()
+		return nil
+	}
+
+	// The lock makes sure that Z3_interrupt is never called after this
+	// function has returned, which would leak the interrupt into an
+	// unrelated call. It may still be called after f has returned, before
+	// finished is set; the context is then reset below like after any
+	// interrupt.
+	var lock sync.Mutex
+	finished, interrupted := false, false
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-done:
+			lock.Lock()
+			defer lock.Unlock()
+			if !finished {
+				interrupted = true
+				interruptedMapLock.Lock()
+				interruptedMap[rawCtx] = true
+				interruptedMapLock.Unlock()
+				C.Z3_interrupt(rawCtx)
+			}
+		case <-stop:
+		}
+	}()
+
+	conclusive := f()
+	close(stop)
+
+	lock.Lock()
+	defer lock.Unlock()
+	finished = true
+	if !interrupted {
+		return nil
+	}
+
+	interruptedMapLock.Lock()
+	delete(interruptedMap, rawCtx)
+	interruptedMapLock.Unlock()
+
+	// Z3_interrupt leaves the context canceled until the next solver check
+	// resets it. Run a check on an empty solver so that later calls, tactics
+	// in particular, are not canceled as well.
+	rawSolver := C.Z3_mk_simple_solver(rawCtx)
+	C.Z3_solver_inc_ref(rawCtx, rawSolver)
+	C.Z3_solver_check(rawCtx, rawSolver)
+	C.Z3_solver_dec_ref(rawCtx, rawSolver)
+
+	if conclusive {
+		return nil
+	}
+	return &CanceledError{Err: ctx.Err()}
+}
@@ -0,0 +1,149 @@
+package z3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// assertPigeonhole asserts that n+1 pigeons fit in n holes, which is
+// unsatisfiable but takes the solver a long time to prove.
+func assertPigeonhole(ctx *Context, n int, assert func(*AST)) {
+	p := make([][]*AST, n+1)
+	for i := range p {
+		p[i] = make([]*AST, n)
+		for j := range p[i] {
+			p[i][j] = ctx.Const(ctx.Symbol(fmt.Sprintf("p_%d_%d", i, j)), ctx.BoolSort())
+		}
+		assert(p[i][0].Or(p[i][1:]...))
+	}
+	for j := 0; j < n; j++ {
+		for i := range p {
+			for k := i + 1; k < len(p); k++ {
+				assert(p[i][j].And(p[k][j]).Not())
+			}
+		}
+	}
+}
+
+func TestSolverCheckContext(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	assertPigeonhole(ctx, 12, s.Assert)
+
+	goCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result, err := s.CheckContext(goCtx)
+	if result != Undef {
+		t.Fatalf("bad: %v", result)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err: %v", err)
+	}
+	var canceled *CanceledError
+	if !errors.As(err, &canceled) {
+		t.Fatalf("err: %v", err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Fatalf("interrupt took %s", d)
+	}
+
+	// The context is usable afterwards
+	s2 := ctx.MkSolver()
+	defer s2.Close()
+	s2.Assert(ctx.Const(ctx.Symbol("x"), ctx.BoolSort()))
+	result, err = s2.CheckContext(context.Background())
+	if result != True || err != nil {
+		t.Fatalf("bad: %v %v", result, err)
+	}
+}
+
+func TestSolverCheckContext_canceled(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	goCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := s.CheckContext(goCtx)
+	if result != Undef {
+		t.Fatalf("bad: %v", result)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestOptimizeCheckContext(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	o := ctx.MkOptimize()
+	defer o.Close()
+	assertPigeonhole(ctx, 12, o.Add)
+
+	goCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	result, err := o.CheckContext(goCtx)
+	if result != Undef {
+		t.Fatalf("bad: %v", result)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestTacticApplyContext(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	g := ctx.MkGoal(true, false, false)
+	defer g.Close()
+	assertPigeonhole(ctx, 12, g.Assert)
+
+	tactic := ctx.MkTactic("smt")
+	defer tactic.Close()
+
+	goCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	r, err := tactic.ApplyContext(goCtx, g)
+	if r != nil {
+		t.Fatalf("bad: %s", r)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Without cancellation the tactic runs to completion
+	g2 := ctx.MkGoal(true, false, false)
+	defer g2.Close()
+	g2.Assert(ctx.Const(ctx.Symbol("x"), ctx.BoolSort()))
+	r, err = tactic.ApplyContext(context.Background(), g2)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer r.Close()
+	if r.GetNumSubgoals() != 1 {
+		t.Fatalf("bad: %s", r)
+	}
+}
@@ -1,5 +1,10 @@
 package z3
 
+import (
+	"fmt"
+	"runtime"
+)
+
 // #include "go-z3.h"
 /*
 int _Z3_model_eval(Z3_context c, Z3_model m, Z3_ast t, int model_completion, Z3_ast * v) {
@@ -11,17 +16,36 @@ import "C"
 
 // Model represents a model from a solver.
 //
-// Memory management for this is manual and based on reference counting.
-// When a model is initialized (via Solver.Model for example), it always has
-// a reference count of 1. You must call Close when you're done.
+// Memory management for this is based on reference counting. The model is
+// freed once it is garbage collected, or right away by calling Close when
+// you're done.
 type Model struct {
 	rawCtx   C.Z3_context
 	rawModel C.Z3_model
 }
 
+// newModel wraps a model returned by Z3 and takes a reference to it,
+// which is released by Close or once the Model is garbage collected.
+func newModel(rawCtx C.Z3_context, rawModel C.Z3_model) *Model {
+	checkError(rawCtx)
+	m := &Model{
+		rawCtx:   rawCtx,
+		rawModel: rawModel,
+	}
+	if rawModel != nil {
+		C.Z3_model_inc_ref(rawCtx, rawModel)
+		track(rawCtx, m, func() {
+			C.Z3_model_dec_ref(rawCtx, rawModel)
+		})
+	}
+	return m
+}
+
 // String returns a human-friendly string version of the model.
 func (m *Model) String() string {
-	return C.GoString(C.Z3_model_to_string(m.rawCtx, m.rawModel))
+	result := C.GoString(C.Z3_model_to_string(m.rawCtx, m.rawModel))
+	checkError(m.rawCtx)
+	return result
 }
 
 //-------------------------------------------------------------------
@@ -44,10 +68,7 @@ func (m *Model) Eval(c *AST) *AST {
 		return nil
 	}
 
-	return &AST{
-		rawCtx: m.rawCtx,
-		rawAST: result,
-	}
+	return newAST(m.rawCtx, result)
 }
 
 // Assignments returns a map of all the assignments for all the constants
@@ -60,20 +81,16 @@ func (m *Model) Assignments() map[string]*AST {
 	result := make(map[string]*AST)
 	for i := uint(0); i < m.NumConsts(); i++ {
 		// Get the declaration
-		decl := m.ConstDecl(i)
+		decl := m.ConstFuncDecl(i)
 
 		// Get the name of it, i.e. "x"
-		name := decl.DeclName()
+		name := decl.Name()
 
 		// Get the assignment for this
-		ast := C.Z3_model_get_const_interp(
-			m.rawCtx, m.rawModel, C.Z3_to_func_decl(decl.rawCtx, decl.rawAST))
+		ast := C.Z3_model_get_const_interp(m.rawCtx, m.rawModel, decl.rawFuncDecl)
 
 		// Map it
-		result[name.String()] = &AST{
-			rawCtx: m.rawCtx,
-			rawAST: ast,
-		}
+		result[name.String()] = newAST(m.rawCtx, ast)
 	}
 
 	return result
@@ -83,19 +100,98 @@ func (m *Model) Assignments() map[string]*AST {
 //
 // Maps: Z3_model_get_num_consts
 func (m *Model) NumConsts() uint {
-	return uint(C.Z3_model_get_num_consts(m.rawCtx, m.rawModel))
+	result := uint(C.Z3_model_get_num_consts(m.rawCtx, m.rawModel))
+	checkError(m.rawCtx)
+	return result
 }
 
-// ConstDecl returns the const declaration for the given index. idx must
-// be less than NumConsts.
+// ConstDecl returns the const declaration for the given index as an AST.
+// idx must be less than NumConsts. ConstFuncDecl returns it as a FuncDecl.
 //
 // Maps: Z3_model_get_const_decl
 func (m *Model) ConstDecl(idx uint) *AST {
-	return &AST{
-		rawCtx: m.rawCtx,
-		rawAST: C.Z3_func_decl_to_ast(
-			m.rawCtx,
-			C.Z3_model_get_const_decl(m.rawCtx, m.rawModel, C.uint(idx))),
+	return m.ConstFuncDecl(idx).AST()
+}
+
+// ConstFuncDecl returns the const declaration for the given index. idx
+// must be less than NumConsts.
+//
+// Maps: Z3_model_get_const_decl
+func (m *Model) ConstFuncDecl(idx uint) *FuncDecl {
+	return newFuncDecl(m.rawCtx, C.Z3_model_get_const_decl(m.rawCtx, m.rawModel, C.uint(idx)))
+}
+
+// ArrayValue returns the concrete value of an array term in the model as
+// a list of explicit entries and a default value.
+//
+// Z3 represents array values either as nested stores on top of a constant
+// array, or as a reference to an auxiliary function in the model; both
+// forms are decoded here.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (m *Model) ArrayValue(a *AST) (*ArrayValue, error) {
+	v := m.Eval(a)
+	if v == nil {
+		return nil, fmt.Errorf("cannot evaluate %s", a.String())
+	}
+
+	result := &ArrayValue{}
+	seen := make(map[string]bool)
+	add := func(idx []*AST, val *AST) {
+		// Outer stores shadow inner ones, so keep the first occurrence.
+		e := ArrayEntry{Index: idx, Value: val}
+		key := fmt.Sprint(idx)
+		if !seen[key] {
+			seen[key] = true
+			result.Entries = append(result.Entries, e)
+		}
+	}
+	wrap := func(raw C.Z3_ast) *AST {
+		return newAST(m.rawCtx, raw)
+	}
+
+	// raw points into v, which must stay alive while values are created.
+	defer runtime.KeepAlive(v)
+	raw := v.rawAST
+	for {
+		if bool(C.Z3_is_as_array(m.rawCtx, raw)) {
+			fi := m.FuncInterp(newFuncDecl(m.rawCtx, C.Z3_get_as_array_func_decl(m.rawCtx, raw)))
+			if fi == nil {
+				return nil, fmt.Errorf("no interpretation for %s", v.String())
+			}
+			defer fi.Close()
+
+			for _, e := range fi.Entries() {
+				add(e.Args, e.Value)
+			}
+			result.Default = fi.Else()
+			return result, nil
+		}
+
+		if C.Z3_get_ast_kind(m.rawCtx, raw) != C.Z3_APP_AST {
+			return nil, fmt.Errorf("unsupported array value: %s", v.String())
+		}
+		app := C.Z3_to_app(m.rawCtx, raw)
+		switch C.Z3_get_decl_kind(m.rawCtx, C.Z3_get_app_decl(m.rawCtx, app)) {
+		case C.Z3_OP_STORE:
+			// (store array idx_1 ... idx_n value)
+			nargs := uint(C.Z3_get_app_num_args(m.rawCtx, app))
+			checkError(m.rawCtx)
+			idx := make([]*AST, nargs-2)
+			for j := uint(1); j < nargs-1; j++ {
+				idx[j-1] = wrap(C.Z3_get_app_arg(m.rawCtx, app, C.uint(j)))
+			}
+			add(idx, wrap(C.Z3_get_app_arg(m.rawCtx, app, C.uint(nargs-1))))
+			raw = C.Z3_get_app_arg(m.rawCtx, app, 0)
+
+		case C.Z3_OP_CONST_ARRAY:
+			result.Default = wrap(C.Z3_get_app_arg(m.rawCtx, app, 0))
+			return result, nil
+
+		default:
+			return nil, fmt.Errorf("unsupported array value: %s", v.String())
+		}
 	}
 }
 
@@ -103,6 +199,6 @@ func (m *Model) ConstDecl(idx uint) *AST {
 // has manually increased the reference count, this will free the memory
 // associated with it.
 func (m *Model) Close() error {
-	C.Z3_model_dec_ref(m.rawCtx, m.rawModel)
+	untrack(m.rawCtx, m)
 	return nil
 }
\ No newline at end of file
@@ -44,6 +44,16 @@ func TestModelAssignments(t *testing.T) {
 	if assign.Int() != 12 {
 		t.Fatalf("bad: %s", assign)
 	}
+
+	if n := m.NumConsts(); n != 1 {
+		t.Fatalf("bad: %d", n)
+	}
+	if decl := m.ConstFuncDecl(0); decl.Name().String() != "x" || decl.Arity() != 0 {
+		t.Fatalf("bad: %s", decl)
+	}
+	if v := m.ConstDecl(0).DeclName().String(); v != "x" {
+		t.Fatalf("bad: %s", v)
+	}
 }
 
 func TestModelEval(t *testing.T) {
@@ -1,5 +1,9 @@
 package z3
 
+import (
+	"context"
+)
+
 // #include "go-z3.h"
 import "C"
 
@@ -8,78 +12,120 @@ type Optimize struct {
 	rawOptimize C.Z3_optimize
 }
 
-// NewOptimize creates a new optimize.
-func (c *Context) MkOptimize() *Optimize {
-	rawOptimize := C.Z3_mk_optimize(c.rawCtx)
-	C.Z3_optimize_inc_ref(c.rawCtx, rawOptimize)
-	return &Optimize{
-		rawCtx:      c.rawCtx,
+// newOptimize wraps an optimize returned by Z3 and takes a reference to it,
+// which is released by Close or once the Optimize is garbage collected.
+func newOptimize(rawCtx C.Z3_context, rawOptimize C.Z3_optimize) *Optimize {
+	checkError(rawCtx)
+	o := &Optimize{
+		rawCtx:      rawCtx,
 		rawOptimize: rawOptimize,
 	}
+	if rawOptimize != nil {
+		C.Z3_optimize_inc_ref(rawCtx, rawOptimize)
+		track(rawCtx, o, func() {
+			C.Z3_optimize_dec_ref(rawCtx, rawOptimize)
+		})
+	}
+	return o
+}
+
+// NewOptimize creates a new optimize.
+func (c *Context) MkOptimize() *Optimize {
+	return newOptimize(c.rawCtx, C.Z3_mk_optimize(c.rawCtx))
 }
 
 // Close frees the memory associated with this.
 func (s *Optimize) Close() error {
-	C.Z3_optimize_dec_ref(s.rawCtx, s.rawOptimize)
+	untrack(s.rawCtx, s)
 	return nil
 }
 
+// SetParams sets the parameters of the optimize. The parameters it
+// accepts are described by ParamDescrs.
+//
+// Maps to: Z3_optimize_set_params
+func (s *Optimize) SetParams(p *Params) {
+	C.Z3_optimize_set_params(s.rawCtx, s.rawOptimize, p.rawParams)
+	checkError(s.rawCtx)
+}
+
 // Add adds a constraint onto the Optimize.
 //
 // Maps to: Z3_optimize_assert
 func (s *Optimize) Add(a *AST) {
 	C.Z3_optimize_assert(s.rawCtx, s.rawOptimize, a.rawAST)
+	checkError(s.rawCtx)
 }
 
 // Maximize adds a function to maximize and returns a handle to be later used in Lower or Upper.
 //
 // Maps to: Z3_optimize_maximize
 func (s *Optimize) Maximize(a *AST) (handle uint) {
-	return uint(C.Z3_optimize_maximize(s.rawCtx, s.rawOptimize, a.rawAST))
+	result := uint(C.Z3_optimize_maximize(s.rawCtx, s.rawOptimize, a.rawAST))
+	checkError(s.rawCtx)
+	return result
 }
 
 // Minimize adds a function to minimize and returns a handle to be later used in Lower or Upper.
 //
 // Maps to: Z3_optimize_minimize
 func (s *Optimize) Minimize(a *AST) (handle uint) {
-	return uint(C.Z3_optimize_minimize(s.rawCtx, s.rawOptimize, a.rawAST))
+	result := uint(C.Z3_optimize_minimize(s.rawCtx, s.rawOptimize, a.rawAST))
+	checkError(s.rawCtx)
+	return result
 }
 
 // Lower returns a lower value or the current approximation.
 //
 // Maps to: Z3_optimize_get_lower
 func (s *Optimize) Lower(handle uint) *AST {
-	return &AST{
-		rawCtx: s.rawCtx,
-		rawAST: C.Z3_optimize_get_lower(s.rawCtx, s.rawOptimize, C.uint(handle)),
-	}
+	return newAST(s.rawCtx, C.Z3_optimize_get_lower(s.rawCtx, s.rawOptimize, C.uint(handle)))
 }
 
 // Upper returns an upper value or the current approximation.
 //
 // Maps to: Z3_optimize_get_upper
 func (s *Optimize) Upper(handle uint) *AST {
-	return &AST{
-		rawCtx: s.rawCtx,
-		rawAST: C.Z3_optimize_get_upper(s.rawCtx, s.rawOptimize, C.uint(handle)),
-	}
+	return newAST(s.rawCtx, C.Z3_optimize_get_upper(s.rawCtx, s.rawOptimize, C.uint(handle)))
 }
 
 // Check checks if the currently set formula is consistent.
 //
 // Maps to: Z3_optimize_check
 func (s *Optimize) Check() LBool {
-	return LBool(C.Z3_optimize_check(s.rawCtx, s.rawOptimize, 0, nil))
+	result := LBool(C.Z3_optimize_check(s.rawCtx, s.rawOptimize, 0, nil))
+	checkError(s.rawCtx)
+	return result
+}
+
+// CheckContext is like Check, but interrupts the check when ctx is
+// canceled or its deadline passes. An interrupted check returns Undef and
+// a *CanceledError.
+//
+// Maps to: Z3_optimize_check, Z3_interrupt
+func (s *Optimize) CheckContext(ctx context.Context) (LBool, error) {
+	result := LBool(Undef)
+	err := interruptible(ctx, s.rawCtx, func() bool {
+		result = s.Check()
+		return result != Undef
+	})
+	return result, err
+}
+
+// ReasonUnknown returns a brief explanation of why the last check
+// returned Undef, such as "timeout", "interrupted" or
+// "incomplete quantifiers".
+//
+// Maps to: Z3_optimize_get_reason_unknown
+func (s *Optimize) ReasonUnknown() string {
+	result := C.GoString(C.Z3_optimize_get_reason_unknown(s.rawCtx, s.rawOptimize))
+	checkError(s.rawCtx)
+	return result
 }
 
 // Model returns the last model from a Check.
 //
 // Maps to: Z3_optimize_get_model
 func (s *Optimize) Model() *Model {
-	m := &Model{
-		rawCtx:   s.rawCtx,
-		rawModel: C.Z3_optimize_get_model(s.rawCtx, s.rawOptimize),
-	}
-	C.Z3_model_inc_ref(m.rawCtx, m.rawModel)
-	return m
+	return newModel(s.rawCtx, C.Z3_optimize_get_model(s.rawCtx, s.rawOptimize))
 }
\ No newline at end of file
@@ -0,0 +1,160 @@
+package z3
+
+import (
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "go-z3.h"
+import "C"
+
+// ParamKind is the kind of value a parameter takes.
+type ParamKind int
+
+const (
+	ParamKindUint    ParamKind = C.Z3_PK_UINT
+	ParamKindBool    ParamKind = C.Z3_PK_BOOL
+	ParamKindDouble  ParamKind = C.Z3_PK_DOUBLE
+	ParamKindSymbol  ParamKind = C.Z3_PK_SYMBOL
+	ParamKindString  ParamKind = C.Z3_PK_STRING
+	ParamKindOther   ParamKind = C.Z3_PK_OTHER
+	ParamKindInvalid ParamKind = C.Z3_PK_INVALID
+)
+
+func (k ParamKind) String() string {
+	switch k {
+	case ParamKindUint:
+		return "unsigned int"
+	case ParamKindBool:
+		return "bool"
+	case ParamKindDouble:
+		return "double"
+	case ParamKindSymbol:
+		return "symbol"
+	case ParamKindString:
+		return "string"
+	case ParamKindOther:
+		return "other"
+	case ParamKindInvalid:
+		return "invalid"
+	default:
+		panic("Unknown ParamKind")
+	}
+}
+
+// ParamDescrs describes the parameters accepted by a solver, tactic,
+// optimize or the simplifier: their names, kinds and documentation. Use
+// Params.Validate to check parameters against it.
+//
+// ParamDescrs memory management is automatic, like it is for AST.
+type ParamDescrs struct {
+	rawCtx         C.Z3_context
+	rawParamDescrs C.Z3_param_descrs
+}
+
+// newParamDescrs wraps parameter descriptions returned by Z3 and takes a
+// reference to them, which is released by Close or once the ParamDescrs
+// is garbage collected.
+func newParamDescrs(rawCtx C.Z3_context, rawParamDescrs C.Z3_param_descrs) *ParamDescrs {
+	checkError(rawCtx)
+	d := &ParamDescrs{
+		rawCtx:         rawCtx,
+		rawParamDescrs: rawParamDescrs,
+	}
+	if rawParamDescrs != nil {
+		C.Z3_param_descrs_inc_ref(rawCtx, rawParamDescrs)
+		track(rawCtx, d, func() {
+			C.Z3_param_descrs_dec_ref(rawCtx, rawParamDescrs)
+		})
+	}
+	return d
+}
+
+// ParamDescrs returns the descriptions of the parameters the solver
+// accepts.
+//
+// Maps to: Z3_solver_get_param_descrs
+func (s *Solver) ParamDescrs() *ParamDescrs {
+	return newParamDescrs(s.rawCtx, C.Z3_solver_get_param_descrs(s.rawCtx, s.rawSolver))
+}
+
+// ParamDescrs returns the descriptions of the parameters the tactic
+// accepts.
+//
+// Maps to: Z3_tactic_get_param_descrs
+func (t *Tactic) ParamDescrs() *ParamDescrs {
+	return newParamDescrs(t.rawCtx, C.Z3_tactic_get_param_descrs(t.rawCtx, t.rawTactic))
+}
+
+// ParamDescrs returns the descriptions of the parameters the optimize
+// accepts.
+//
+// Maps to: Z3_optimize_get_param_descrs
+func (s *Optimize) ParamDescrs() *ParamDescrs {
+	return newParamDescrs(s.rawCtx, C.Z3_optimize_get_param_descrs(s.rawCtx, s.rawOptimize))
+}
+
+// SimplifyParamDescrs returns the descriptions of the parameters accepted
+// by AST.SimplifyEx.
+//
+// Maps to: Z3_simplify_get_param_descrs
+func (c *Context) SimplifyParamDescrs() *ParamDescrs {
+	return newParamDescrs(c.rawCtx, C.Z3_simplify_get_param_descrs(c.rawCtx))
+}
+
+// String returns a human-friendly string version of the descriptions.
+//
+// Maps to: Z3_param_descrs_to_string
+func (d *ParamDescrs) String() string {
+	result := C.GoString(C.Z3_param_descrs_to_string(d.rawCtx, d.rawParamDescrs))
+	checkError(d.rawCtx)
+	return result
+}
+
+// Names returns the names of the described parameters.
+//
+// Maps to: Z3_param_descrs_size, Z3_param_descrs_get_name
+func (d *ParamDescrs) Names() []string {
+	n := uint(C.Z3_param_descrs_size(d.rawCtx, d.rawParamDescrs))
+	checkError(d.rawCtx)
+	result := make([]string, n)
+	for i := uint(0); i < n; i++ {
+		result[i] = C.GoString(C.Z3_get_symbol_string(
+			d.rawCtx, C.Z3_param_descrs_get_name(d.rawCtx, d.rawParamDescrs, C.uint(i))))
+		checkError(d.rawCtx)
+	}
+	return result
+}
+
+// Kind returns the kind of the named parameter, or ParamKindInvalid if it
+// is not described.
+//
+// Maps to: Z3_param_descrs_get_kind
+func (d *ParamDescrs) Kind(name string) ParamKind {
+	result := ParamKind(C.Z3_param_descrs_get_kind(d.rawCtx, d.rawParamDescrs, d.symbol(name)))
+	checkError(d.rawCtx)
+	return result
+}
+
+// Documentation returns the documentation of the named parameter.
+//
+// Maps to: Z3_param_descrs_get_documentation
+func (d *ParamDescrs) Documentation(name string) string {
+	result := C.GoString(C.Z3_param_descrs_get_documentation(d.rawCtx, d.rawParamDescrs, d.symbol(name)))
+	checkError(d.rawCtx)
+	return result
+}
+
+// Close decreases the reference count for the descriptions.
+func (d *ParamDescrs) Close() error {
+	untrack(d.rawCtx, d)
+	return nil
+}
+
+func (d *ParamDescrs) symbol(name string) C.Z3_symbol {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	result := C.Z3_mk_string_symbol(d.rawCtx, cname)
+	checkError(d.rawCtx)
+	return result
+}
@@ -1,5 +1,14 @@
 package z3
 
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// #include <stdlib.h>
 // #include "go-z3.h"
 import "C"
 
@@ -9,15 +18,27 @@ type Params struct {
 	rawParams 	C.Z3_params
 }
 
+// newParams wraps a params returned by Z3 and takes a reference to it,
+// which is released by Close or once the Params is garbage collected.
+func newParams(rawCtx C.Z3_context, rawParams C.Z3_params) *Params {
+	checkError(rawCtx)
+	p := &Params{
+		rawCtx:    rawCtx,
+		rawParams: rawParams,
+	}
+	if rawParams != nil {
+		C.Z3_params_inc_ref(rawCtx, rawParams)
+		track(rawCtx, p, func() {
+			C.Z3_params_dec_ref(rawCtx, rawParams)
+		})
+	}
+	return p
+}
+
 
 // MkConfig allocates a new configuration object.
 func (c *Context) MkParams() *Params {
-	rawParams := C.Z3_mk_params(c.rawCtx)
-	C.Z3_params_inc_ref(c.rawCtx, rawParams)
-	return &Params{
-		rawCtx: c.rawCtx,
-		rawParams: rawParams,
-	}
+	return newParams(c.rawCtx, C.Z3_mk_params(c.rawCtx))
 }
 
 
@@ -25,19 +46,127 @@ func (c *Context) MkParams() *Params {
 //
 // Maps: Z3_params_to_string
 func (p *Params) String() string {
-	return C.GoString(C.Z3_params_to_string(p.rawCtx, p.rawParams))
+	result := C.GoString(C.Z3_params_to_string(p.rawCtx, p.rawParams))
+	checkError(p.rawCtx)
+	return result
 }
 
+// SetBool sets a Boolean parameter.
+//
 // Maps: Z3_params_set_bool
 func (p *Params) SetBool(id string, value bool) {
-	C.Z3_params_set_bool(p.rawCtx, p.rawParams,
-		C.Z3_mk_string_symbol(p.rawCtx, C.CString(id)), C.bool(value))
+	C.Z3_params_set_bool(p.rawCtx, p.rawParams, p.symbol(id), C.bool(value))
+	checkError(p.rawCtx)
+}
+
+// SetUint sets an unsigned integer parameter. Z3 takes 32-bit values, so
+// SetUint panics if value doesn't fit in 32 bits.
+//
+// Maps: Z3_params_set_uint
+func (p *Params) SetUint(id string, value uint) {
+	if uint64(value) > math.MaxUint32 {
+		panic("SetUint: value does not fit in 32 bits")
+	}
+	C.Z3_params_set_uint(p.rawCtx, p.rawParams, p.symbol(id), C.uint(value))
+	checkError(p.rawCtx)
+}
+
+// SetDouble sets a floating point parameter.
+//
+// Maps: Z3_params_set_double
+func (p *Params) SetDouble(id string, value float64) {
+	C.Z3_params_set_double(p.rawCtx, p.rawParams, p.symbol(id), C.double(value))
+	checkError(p.rawCtx)
+}
+
+// SetSymbol sets a symbol parameter, such as the logic of a solver. Z3 also
+// takes the values of string parameters as symbols.
+//
+// Maps: Z3_params_set_symbol
+func (p *Params) SetSymbol(id string, value string) {
+	C.Z3_params_set_symbol(p.rawCtx, p.rawParams, p.symbol(id), p.symbol(value))
+	checkError(p.rawCtx)
+}
+
+// Set sets a parameter with the setter matching the type of value: bool,
+// any integer type, float32 or float64, string or *Symbol. An error is
+// returned for other types and negative integers. Whether the parameter
+// has that kind is checked by Validate.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (p *Params) Set(id string, value interface{}) error {
+	switch v := value.(type) {
+	case bool:
+		p.SetBool(id, v)
+	case string:
+		p.SetSymbol(id, v)
+	case *Symbol:
+		C.Z3_params_set_symbol(p.rawCtx, p.rawParams, p.symbol(id), v.rawSymbol)
+		checkError(p.rawCtx)
+	case float32:
+		p.SetDouble(id, float64(v))
+	case float64:
+		p.SetDouble(id, v)
+	default:
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rv.Int() < 0 {
+				return fmt.Errorf("z3: parameter %s: negative value %d", id, rv.Int())
+			}
+			if rv.Int() > math.MaxUint32 {
+				return fmt.Errorf("z3: parameter %s: value %d does not fit in 32 bits", id, rv.Int())
+			}
+			p.SetUint(id, uint(rv.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if rv.Uint() > math.MaxUint32 {
+				return fmt.Errorf("z3: parameter %s: value %d does not fit in 32 bits", id, rv.Uint())
+			}
+			p.SetUint(id, uint(rv.Uint()))
+		default:
+			return fmt.Errorf("z3: parameter %s: unsupported type %T", id, value)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the parameters are described by d, such as the
+// ParamDescrs of the solver or tactic they will be applied to, and have
+// the described kinds. Otherwise a *Z3Error is returned, describing the
+// first unknown name or wrong kind.
+//
+// Maps: Z3_params_validate
+func (p *Params) Validate(d *ParamDescrs) error {
+	var err error
+	(&Context{rawCtx: p.rawCtx}).returnErrors(func() {
+		C.Z3_params_validate(p.rawCtx, p.rawParams, d.rawParamDescrs)
+		if code := C.Z3_get_error_code(p.rawCtx); code != C.Z3_OK {
+			// Drop the list of legal parameters that follows the first line,
+			// d describes them.
+			z3err := newZ3Error(p.rawCtx, code)
+			if i := strings.IndexByte(z3err.Msg, '\n'); i >= 0 {
+				z3err.Msg = z3err.Msg[:i]
+			}
+			err = z3err
+		}
+	})
+	return err
+}
+
+// symbol returns the symbol for a parameter name or value.
+func (p *Params) symbol(name string) C.Z3_symbol {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	result := C.Z3_mk_string_symbol(p.rawCtx, cname)
+	checkError(p.rawCtx)
+	return result
 }
 
 // Close decreases the reference count for this params. If nothing else
 // has manually increased the reference count, this will free the memory
 // associated with it.
 func (p *Params) Close() error {
-	C.Z3_params_dec_ref(p.rawCtx, p.rawParams)
+	untrack(p.rawCtx, p)
 	return nil
 }
\ No newline at end of file
@@ -1,7 +1,9 @@
 package z3
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -15,4 +17,127 @@ func TestParams_SetBool(t *testing.T) {
 
 	params.SetBool("ctx-solver-simplify", true)
 	fmt.Printf("%v\n", params.String())
-}
\ No newline at end of file
+}
+func TestParamsSet(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	params := ctx.MkParams()
+	defer params.Close()
+	params.SetUint("max_conflicts", 100)
+	params.SetDouble("restart_factor", 1.5)
+	params.SetSymbol("logic", "QF_LIA")
+	if err := params.Set("random_seed", 42); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := params.Set("mbqi", false); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := params.Set("random_seed", -1); err == nil {
+		t.Fatal("should fail")
+	}
+	if err := params.Set("random_seed", []int{1}); err == nil {
+		t.Fatal("should fail")
+	}
+	if err := params.Set("random_seed", uint64(1)<<32); err == nil {
+		t.Fatal("should fail")
+	}
+
+	for _, v := range []string{"max_conflicts 100", "restart_factor 1.5", "logic QF_LIA", "random_seed 42", "mbqi false"} {
+		if !strings.Contains(params.String(), v) {
+			t.Fatalf("%q not in %s", v, params)
+		}
+	}
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	if err := params.Validate(s.ParamDescrs()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	s.SetParams(params)
+
+	// Z3 takes 32-bit unsigned values
+	defer func() {
+		if r := recover(); r != "SetUint: value does not fit in 32 bits" {
+			t.Fatalf("bad: %v", r)
+		}
+	}()
+	big := uint64(1) << 32
+	params.SetUint("max_conflicts", uint(big))
+}
+
+func TestParamsValidate(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	descrs := ctx.SimplifyParamDescrs()
+
+	// Unknown name
+	params := ctx.MkParams()
+	params.SetBool("no_such_param", true)
+	err := params.Validate(descrs)
+	var z3err *Z3Error
+	if !errors.As(err, &z3err) || z3err.Msg != "unknown parameter 'no_such_param'" {
+		t.Fatalf("bad: %#v", err)
+	}
+
+	// Wrong kind
+	params = ctx.MkParams()
+	params.SetUint("som", 1)
+	err = params.Validate(descrs)
+	if !errors.As(err, &z3err) || !strings.Contains(z3err.Msg, "expected bool") {
+		t.Fatalf("bad: %#v", err)
+	}
+
+	params = ctx.MkParams()
+	params.SetBool("som", true)
+	if err := params.Validate(descrs); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	if v := x.Mul(x.Add(ctx.Int(1, ctx.IntSort()))).SimplifyEx(params).String(); v != "(+ x (* x x))" {
+		t.Fatalf("bad: %s", v)
+	}
+}
+
+func TestParamDescrs(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	tactic := ctx.MkTactic("simplify")
+	defer tactic.Close()
+	descrs := tactic.ParamDescrs()
+	defer descrs.Close()
+
+	found := false
+	for _, name := range descrs.Names() {
+		found = found || name == "som"
+	}
+	if !found {
+		t.Fatalf("som not in %v", descrs.Names())
+	}
+	if k := descrs.Kind("som"); k != ParamKindBool {
+		t.Fatalf("bad: %s", k)
+	}
+	if k := descrs.Kind("max_steps"); k != ParamKindUint {
+		t.Fatalf("bad: %s", k)
+	}
+	if k := descrs.Kind("no_such_param"); k != ParamKindInvalid {
+		t.Fatalf("bad: %s", k)
+	}
+	if v := descrs.Documentation("som"); !strings.Contains(v, "sum-of-monomials") {
+		t.Fatalf("bad: %s", v)
+	}
+
+	o := ctx.MkOptimize()
+	defer o.Close()
+	if k := o.ParamDescrs().Kind("priority"); k != ParamKindSymbol {
+		t.Fatalf("bad: %s", k)
+	}
+}
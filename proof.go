@@ -0,0 +1,233 @@
+package z3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// #include "go-z3.h"
+import "C"
+
+// ProofRule is the inference rule that justifies a step of a proof. See
+// the Z3_OP_PR_* documentation of Z3 for the meaning of each rule and the
+// shape of its premises.
+type ProofRule int
+
+const (
+	ProofRuleUndef            ProofRule = C.Z3_OP_PR_UNDEF
+	ProofRuleTrue             ProofRule = C.Z3_OP_PR_TRUE
+	ProofRuleAsserted         ProofRule = C.Z3_OP_PR_ASSERTED
+	ProofRuleGoal             ProofRule = C.Z3_OP_PR_GOAL
+	ProofRuleModusPonens      ProofRule = C.Z3_OP_PR_MODUS_PONENS
+	ProofRuleReflexivity      ProofRule = C.Z3_OP_PR_REFLEXIVITY
+	ProofRuleSymmetry         ProofRule = C.Z3_OP_PR_SYMMETRY
+	ProofRuleTransitivity     ProofRule = C.Z3_OP_PR_TRANSITIVITY
+	ProofRuleTransitivityStar ProofRule = C.Z3_OP_PR_TRANSITIVITY_STAR
+	ProofRuleMonotonicity     ProofRule = C.Z3_OP_PR_MONOTONICITY
+	ProofRuleQuantIntro       ProofRule = C.Z3_OP_PR_QUANT_INTRO
+	ProofRuleBind             ProofRule = C.Z3_OP_PR_BIND
+	ProofRuleDistributivity   ProofRule = C.Z3_OP_PR_DISTRIBUTIVITY
+	ProofRuleAndElim          ProofRule = C.Z3_OP_PR_AND_ELIM
+	ProofRuleNotOrElim        ProofRule = C.Z3_OP_PR_NOT_OR_ELIM
+	ProofRuleRewrite          ProofRule = C.Z3_OP_PR_REWRITE
+	ProofRuleRewriteStar      ProofRule = C.Z3_OP_PR_REWRITE_STAR
+	ProofRulePullQuant        ProofRule = C.Z3_OP_PR_PULL_QUANT
+	ProofRulePushQuant        ProofRule = C.Z3_OP_PR_PUSH_QUANT
+	ProofRuleElimUnusedVars   ProofRule = C.Z3_OP_PR_ELIM_UNUSED_VARS
+	ProofRuleDER              ProofRule = C.Z3_OP_PR_DER
+	ProofRuleQuantInst        ProofRule = C.Z3_OP_PR_QUANT_INST
+	ProofRuleHypothesis       ProofRule = C.Z3_OP_PR_HYPOTHESIS
+	ProofRuleLemma            ProofRule = C.Z3_OP_PR_LEMMA
+	ProofRuleUnitResolution   ProofRule = C.Z3_OP_PR_UNIT_RESOLUTION
+	ProofRuleIffTrue          ProofRule = C.Z3_OP_PR_IFF_TRUE
+	ProofRuleIffFalse         ProofRule = C.Z3_OP_PR_IFF_FALSE
+	ProofRuleCommutativity    ProofRule = C.Z3_OP_PR_COMMUTATIVITY
+	ProofRuleDefAxiom         ProofRule = C.Z3_OP_PR_DEF_AXIOM
+	ProofRuleAssumptionAdd    ProofRule = C.Z3_OP_PR_ASSUMPTION_ADD
+	ProofRuleLemmaAdd         ProofRule = C.Z3_OP_PR_LEMMA_ADD
+	ProofRuleRedundantDel     ProofRule = C.Z3_OP_PR_REDUNDANT_DEL
+	ProofRuleClauseTrail      ProofRule = C.Z3_OP_PR_CLAUSE_TRAIL
+	ProofRuleDefIntro         ProofRule = C.Z3_OP_PR_DEF_INTRO
+	ProofRuleApplyDef         ProofRule = C.Z3_OP_PR_APPLY_DEF
+	ProofRuleIffOEq           ProofRule = C.Z3_OP_PR_IFF_OEQ
+	ProofRuleNNFPos           ProofRule = C.Z3_OP_PR_NNF_POS
+	ProofRuleNNFNeg           ProofRule = C.Z3_OP_PR_NNF_NEG
+	ProofRuleSkolemize        ProofRule = C.Z3_OP_PR_SKOLEMIZE
+	ProofRuleModusPonensOEq   ProofRule = C.Z3_OP_PR_MODUS_PONENS_OEQ
+	ProofRuleTheoryLemma      ProofRule = C.Z3_OP_PR_TH_LEMMA
+	ProofRuleHyperResolve     ProofRule = C.Z3_OP_PR_HYPER_RESOLVE
+)
+
+// proofRuleNames are the names Z3 uses for the rules when printing proofs.
+var proofRuleNames = map[ProofRule]string{
+	ProofRuleUndef:            "undef",
+	ProofRuleTrue:             "true-axiom",
+	ProofRuleAsserted:         "asserted",
+	ProofRuleGoal:             "goal",
+	ProofRuleModusPonens:      "mp",
+	ProofRuleReflexivity:      "refl",
+	ProofRuleSymmetry:         "symm",
+	ProofRuleTransitivity:     "trans",
+	ProofRuleTransitivityStar: "trans*",
+	ProofRuleMonotonicity:     "monotonicity",
+	ProofRuleQuantIntro:       "quant-intro",
+	ProofRuleBind:             "proof-bind",
+	ProofRuleDistributivity:   "distributivity",
+	ProofRuleAndElim:          "and-elim",
+	ProofRuleNotOrElim:        "not-or-elim",
+	ProofRuleRewrite:          "rewrite",
+	ProofRuleRewriteStar:      "rewrite*",
+	ProofRulePullQuant:        "pull-quant",
+	ProofRulePushQuant:        "push-quant",
+	ProofRuleElimUnusedVars:   "elim-unused",
+	ProofRuleDER:              "der",
+	ProofRuleQuantInst:        "quant-inst",
+	ProofRuleHypothesis:       "hypothesis",
+	ProofRuleLemma:            "lemma",
+	ProofRuleUnitResolution:   "unit-resolution",
+	ProofRuleIffTrue:          "iff-true",
+	ProofRuleIffFalse:         "iff-false",
+	ProofRuleCommutativity:    "commutativity",
+	ProofRuleDefAxiom:         "def-axiom",
+	ProofRuleAssumptionAdd:    "add-assume",
+	ProofRuleLemmaAdd:         "add-lemma",
+	ProofRuleRedundantDel:     "del-redundant",
+	ProofRuleClauseTrail:      "proof-trail",
+	ProofRuleDefIntro:         "intro-def",
+	ProofRuleApplyDef:         "apply-def",
+	ProofRuleIffOEq:           "iff~",
+	ProofRuleNNFPos:           "nnf-pos",
+	ProofRuleNNFNeg:           "nnf-neg",
+	ProofRuleSkolemize:        "sk",
+	ProofRuleModusPonensOEq:   "mp~",
+	ProofRuleTheoryLemma:      "th-lemma",
+	ProofRuleHyperResolve:     "hyper-res",
+}
+
+func (r ProofRule) String() string {
+	if name, ok := proofRuleNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("ProofRule(%d)", r)
+}
+
+// Proof returns the proof of unsatisfiability of the last check, which
+// must have returned False. Proof generation must be enabled by setting
+// the "proof" parameter of the Config to "true" before the Context is
+// created.
+//
+// The steps of the proof can be inspected with ProofRule, ProofPremises
+// and ProofConclusion, or visited with WalkProof.
+//
+// Maps to: Z3_solver_get_proof
+func (s *Solver) Proof() *AST {
+	return newAST(s.rawCtx, C.Z3_solver_get_proof(s.rawCtx, s.rawSolver))
+}
+
+// IsProof returns true if the AST is a step of a proof.
+func (a *AST) IsProof() bool {
+	if a.Kind() != ASTKindApp {
+		return false
+	}
+	rule := a.ProofRule()
+	return rule >= ProofRuleUndef && rule <= ProofRuleHyperResolve
+}
+
+// ProofRule returns the inference rule of a proof step.
+//
+// Maps to: Z3_get_decl_kind
+func (a *AST) ProofRule() ProofRule {
+	app := C.Z3_to_app(a.rawCtx, a.rawAST)
+	decl := C.Z3_get_app_decl(a.rawCtx, app)
+	result := ProofRule(C.Z3_get_decl_kind(a.rawCtx, decl))
+	checkError(a.rawCtx)
+	return result
+}
+
+// ProofPremises returns the proof steps that a proof step derives its
+// conclusion from. Axioms, such as asserted formulas, have no premises.
+//
+// Maps to: Z3_get_app_arg
+func (a *AST) ProofPremises() []*AST {
+	app := C.Z3_to_app(a.rawCtx, a.rawAST)
+	n := uint(C.Z3_get_app_num_args(a.rawCtx, app))
+	checkError(a.rawCtx)
+
+	// The conclusion is the last argument, all others are premises
+	result := make([]*AST, 0, n)
+	for i := uint(0); i+1 < n; i++ {
+		result = append(result, newAST(a.rawCtx, C.Z3_get_app_arg(a.rawCtx, app, C.uint(i))))
+	}
+	return result
+}
+
+// ProofConclusion returns the formula a proof step proves. The conclusion
+// of the whole proof returned by Solver.Proof is false.
+//
+// Maps to: Z3_get_app_arg
+func (a *AST) ProofConclusion() *AST {
+	app := C.Z3_to_app(a.rawCtx, a.rawAST)
+	n := C.Z3_get_app_num_args(a.rawCtx, app)
+	return newAST(a.rawCtx, C.Z3_get_app_arg(a.rawCtx, app, n-1))
+}
+
+// WalkProof calls f for every step of the proof, premises before the
+// steps derived from them, so that the proof itself is visited last. A
+// step that is the premise of several others is visited once. If f
+// returns an error, the walk stops and returns that error.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (a *AST) WalkProof(f func(step *AST) error) error {
+	visited := make(map[C.uint]bool)
+
+	var walk func(step *AST) error
+	walk = func(step *AST) error {
+		id := C.Z3_get_ast_id(step.rawCtx, step.rawAST)
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+
+		for _, p := range step.ProofPremises() {
+			if err := walk(p); err != nil {
+				return err
+			}
+		}
+		return f(step)
+	}
+	return walk(a)
+}
+
+// WriteProofDOT writes the proof as a Graphviz DOT graph to w. Every step
+// is a node labeled with its rule and conclusion, with an edge from each
+// premise to the steps derived from it.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (a *AST) WriteProofDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph proof {")
+	fmt.Fprintln(bw, "  node [shape=box];")
+
+	err := a.WalkProof(func(step *AST) error {
+		id := C.Z3_get_ast_id(step.rawCtx, step.rawAST)
+		label := step.ProofRule().String() + "\n" + step.ProofConclusion().String()
+		fmt.Fprintf(bw, "  n%d [label=\"%s\"];\n", id, dotEscaper.Replace(label))
+		for _, p := range step.ProofPremises() {
+			fmt.Fprintf(bw, "  n%d -> n%d;\n", C.Z3_get_ast_id(p.rawCtx, p.rawAST), id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotEscaper escapes strings for use in a quoted DOT string.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
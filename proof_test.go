@@ -0,0 +1,100 @@
+package z3
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSolverProof(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	config.SetParamValue("proof", "true")
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	zero := ctx.Int(0, ctx.IntSort())
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	s.Assert(x.Gt(zero))
+	s.Assert(x.Lt(zero))
+
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+
+	proof := s.Proof()
+	t.Logf("\nProof:\n%s", proof)
+	if !proof.IsProof() {
+		t.Fatal("should be a proof")
+	}
+	if c := proof.ProofConclusion().String(); c != "false" {
+		t.Fatalf("bad: %s", c)
+	}
+	if x.IsProof() {
+		t.Fatal("should not be a proof")
+	}
+
+	var steps []*AST
+	asserted := 0
+	err := proof.WalkProof(func(step *AST) error {
+		steps = append(steps, step)
+		if step.ProofRule() == ProofRuleAsserted {
+			asserted++
+			if len(step.ProofPremises()) != 0 {
+				t.Fatalf("bad: %s", step)
+			}
+		}
+
+		// The names of the rules match the ones Z3 prints
+		if !strings.HasPrefix(step.String(), "("+step.ProofRule().String()+" ") {
+			t.Fatalf("bad: %s for %s", step.ProofRule(), step)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if asserted != 2 {
+		t.Fatalf("bad: %d", asserted)
+	}
+	if last := steps[len(steps)-1]; last.String() != proof.String() {
+		t.Fatalf("bad: %s", last)
+	}
+
+	// Errors stop the walk
+	stop := errors.New("stop")
+	visited := 0
+	err = proof.WalkProof(func(step *AST) error {
+		visited++
+		return stop
+	})
+	if err != stop || visited != 1 {
+		t.Fatalf("bad: %v %d", err, visited)
+	}
+
+	var b strings.Builder
+	if err := proof.WriteProofDOT(&b); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	dot := b.String()
+	t.Logf("\nDOT:\n%s", dot)
+	if !strings.HasPrefix(dot, "digraph proof {") {
+		t.Fatalf("bad: %s", dot)
+	}
+	if n := strings.Count(dot, "[label="); n != len(steps) {
+		t.Fatalf("bad: %d nodes for %d steps", n, len(steps))
+	}
+	if !strings.Contains(dot, "asserted") || !strings.Contains(dot, " -> ") {
+		t.Fatalf("bad: %s", dot)
+	}
+}
+
+func TestProofRuleString(t *testing.T) {
+	// Values outside the known rules don't index past the names
+	if v := ProofRule(-1).String(); v != "ProofRule(-1)" {
+		t.Fatalf("bad: %s", v)
+	}
+}
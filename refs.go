@@ -0,0 +1,144 @@
+package z3
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// #include "go-z3.h"
+import "C"
+
+// contextRefs holds the references a context's Go values have to their Z3
+// objects, as the functions that release them.
+//
+// Finalizers run on their own goroutine, while a Z3 context must only be
+// used by one goroutine at a time. So finalizers only queue the release,
+// and the queue is drained by the goroutine using the context the next
+// time it creates a Z3 object.
+//
+// The references that are still live when the context is closed are
+// released before the context is deleted, as Z3 does not expect to find
+// referenced objects then.
+type contextRefs struct {
+	lock    sync.Mutex
+	live    map[uintptr]func()
+	pending []func()
+}
+
+// These unexported vars are used to keep track of the references of every
+// open context.
+var contextRefsMap = map[C.Z3_context]*contextRefs{}
+var contextRefsMapLock sync.RWMutex
+
+// registerContextRefs starts tracking the references of a new context.
+func registerContextRefs(rawCtx C.Z3_context) {
+	contextRefsMapLock.Lock()
+	defer contextRefsMapLock.Unlock()
+	contextRefsMap[rawCtx] = &contextRefs{
+		live: make(map[uintptr]func()),
+	}
+}
+
+// unregisterContextRefs releases all the references of a context that is
+// about to be deleted, and stops tracking them.
+func unregisterContextRefs(rawCtx C.Z3_context) {
+	contextRefsMapLock.Lock()
+	refs := contextRefsMap[rawCtx]
+	delete(contextRefsMap, rawCtx)
+	contextRefsMapLock.Unlock()
+	if refs == nil {
+		return
+	}
+
+	refs.lock.Lock()
+	releases := refs.pending
+	for _, release := range refs.live {
+		releases = append(releases, release)
+	}
+	refs.live = nil
+	refs.pending = nil
+	refs.lock.Unlock()
+
+	for _, release := range releases {
+		release()
+	}
+}
+
+func lookupContextRefs(rawCtx C.Z3_context) *contextRefs {
+	contextRefsMapLock.RLock()
+	defer contextRefsMapLock.RUnlock()
+	return contextRefsMap[rawCtx]
+}
+
+// track arranges for release to be called once obj, a pointer, is closed
+// with untrack or garbage collected. It also runs the releases queued for
+// the context so far, so it must be called after the reference of the new
+// object has been taken.
+//
+// Since creating a value may release others, the raw handles passed to a
+// Z3 call must not be read before creating other values: a value whose
+// handle was read may already be unreachable. Create the operands first,
+// or keep them alive with runtime.KeepAlive.
+func track(rawCtx C.Z3_context, obj interface{}, release func()) {
+	refs := lookupContextRefs(rawCtx)
+	if refs == nil {
+		return
+	}
+	refs.drain()
+
+	// Go values are not moved in memory, and the key is removed before obj
+	// is freed, so its address identifies it.
+	key := reflect.ValueOf(obj).Pointer()
+	refs.lock.Lock()
+	refs.live[key] = release
+	refs.lock.Unlock()
+
+	runtime.SetFinalizer(obj, func(interface{}) {
+		refs.lock.Lock()
+		defer refs.lock.Unlock()
+		if release, ok := refs.live[key]; ok {
+			delete(refs.live, key)
+			refs.pending = append(refs.pending, release)
+		}
+	})
+}
+
+// untrack releases the reference of obj right away. It does nothing if the
+// reference was already released, including by closing the context.
+func untrack(rawCtx C.Z3_context, obj interface{}) {
+	runtime.SetFinalizer(obj, nil)
+	refs := lookupContextRefs(rawCtx)
+	if refs == nil {
+		return
+	}
+
+	key := reflect.ValueOf(obj).Pointer()
+	refs.lock.Lock()
+	release, ok := refs.live[key]
+	delete(refs.live, key)
+	refs.lock.Unlock()
+
+	if ok {
+		release()
+	}
+}
+
+// testHookDrain, if set, is called before the queued releases are run.
+var testHookDrain func()
+
+// drain runs the queued releases.
+func (r *contextRefs) drain() {
+	if testHookDrain != nil {
+		testHookDrain()
+	}
+
+	r.lock.Lock()
+	pending := r.pending
+	r.pending = nil
+	r.lock.Unlock()
+
+	for _, release := range pending {
+		release()
+	}
+}
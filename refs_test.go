@@ -0,0 +1,128 @@
+package z3
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestContextRefsFinalizers(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	refs := lookupContextRefs(ctx.rawCtx)
+
+	liveRefs := func() int {
+		refs.lock.Lock()
+		defer refs.lock.Unlock()
+		return len(refs.live) + len(refs.pending)
+	}
+
+	before := liveRefs()
+	for i := 0; i < 1000; i++ {
+		s := ctx.MkSolver()
+		s.Assert(x.Gt(ctx.Int(i, intTyp)))
+		if result := s.Check(); result != True {
+			t.Fatalf("bad: %v", result)
+		}
+		s.Model()
+	}
+
+	// The finalizers queue the releases, creating a value runs them
+	for i := 0; i < 10 && liveRefs()-before > 100; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+		x.Add(x)
+	}
+	if n := liveRefs() - before; n > 100 {
+		t.Fatalf("%d references were not released", n)
+	}
+}
+
+func TestContextRefsClose(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+
+	s := ctx.MkSolver()
+	s.Assert(x.Gt(ctx.Int(0, intTyp)))
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+	m := s.Model()
+
+	// Closing twice is fine
+	m.Close()
+	m.Close()
+
+	// Values that are still referenced when the context is closed, even
+	// lambdas, are released with it
+	inc := ctx.Lambda([]*AST{x}, x.Add(ctx.Int(1, intTyp)))
+	ctx.Close()
+
+	// Closing values afterwards does nothing
+	s.Close()
+	runtime.KeepAlive(inc)
+}
+
+func TestContextRefsDrainDuringCall(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	// Collect whatever is unreachable each time releases are run, so that
+	// an operand that is not kept alive is released before it is used
+	testHookDrain = func() {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	defer func() { testHookDrain = nil }()
+
+	reals := ctx.RealSet(1.5, 2.5, 3.5)
+	strs := ctx.StringSet("a", "b", "c")
+	digit := ctx.ReRange('0', '9')
+	for _, member := range []*AST{
+		reals.Contain(ctx.Float(1.5)),
+		reals.Contain(ctx.Float(3.5)),
+		strs.Contain(ctx.Str("a")),
+		strs.Contain(ctx.Str("c")),
+		ctx.Str("0").InRe(digit),
+		ctx.Str("9").InRe(digit),
+	} {
+		if !member.Simplify().IsTrue() {
+			t.Fatalf("bad: %s", member)
+		}
+	}
+
+	intTyp := ctx.IntSort()
+	s := ctx.MkSolver()
+	defer s.Close()
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+	m := s.Model()
+	defer m.Close()
+
+	stores := ctx.ConstArray(intTyp, ctx.Int(0, intTyp)).
+		Store(ctx.Int(1, intTyp), ctx.Int(10, intTyp)).
+		Store(ctx.Int(2, intTyp), ctx.Int(20, intTyp))
+	v, err := m.ArrayValue(stores)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	entries := v.Map()
+	if e := entries["1"]; e == nil || e.String() != "10" {
+		t.Fatalf("bad: %v", entries)
+	}
+	if e := entries["2"]; e == nil || e.String() != "20" {
+		t.Fatalf("bad: %v", entries)
+	}
+}
@@ -0,0 +1,212 @@
+package z3
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"unicode"
+)
+
+// maxChar is the largest character Z3 supports in strings.
+const maxChar = 0x2ffff
+
+// ReFromRegexp converts a Go regular expression, compiled with Compile or
+// MustCompile, to a regular expression over strings matching the strings
+// for which re.MatchString is true. Unless re is anchored with ^ or \A at
+// the start and $ or \z at the end, the match may be anywhere in the
+// string. See ReFromSyntax for what is supported.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (c *Context) ReFromRegexp(re *regexp.Regexp) (*AST, error) {
+	tree, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := c.ReSort(c.StringSort())
+	inner, begin, end := stripAnchors(tree)
+	result, err := c.reFromSyntax(inner, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	full := c.ReFull(typ)
+	if !begin {
+		result = full.ReConcat(result)
+	}
+	if !end {
+		result = result.ReConcat(full)
+	}
+	return result, nil
+}
+
+// ReFromSyntax converts a parsed Go regular expression to a regular
+// expression over strings matching the same strings in full. Greedy and
+// non-greedy operators match the same strings, and captures are ignored.
+//
+// Empty-width assertions are only supported as anchors at the start and
+// end of the expression, where they have no effect; \b, \B and the
+// anchors of multi-line mode return an error. Characters above U+2FFFF,
+// which Z3 does not support, are left out of character classes, and
+// literals holding them match nothing.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (c *Context) ReFromSyntax(re *syntax.Regexp) (*AST, error) {
+	inner, _, _ := stripAnchors(re)
+	return c.reFromSyntax(inner, c.ReSort(c.StringSort()))
+}
+
+// stripAnchors removes the \A at the start and the \z at the end of re,
+// and returns whether they were present.
+func stripAnchors(re *syntax.Regexp) (inner *syntax.Regexp, begin, end bool) {
+	switch re.Op {
+	case syntax.OpBeginText:
+		return &syntax.Regexp{Op: syntax.OpEmptyMatch}, true, false
+	case syntax.OpEndText:
+		return &syntax.Regexp{Op: syntax.OpEmptyMatch}, false, true
+	}
+	if re.Op != syntax.OpConcat {
+		return re, false, false
+	}
+
+	subs := re.Sub
+	if len(subs) > 0 && subs[0].Op == syntax.OpBeginText {
+		subs, begin = subs[1:], true
+	}
+	if len(subs) > 0 && subs[len(subs)-1].Op == syntax.OpEndText {
+		subs, end = subs[:len(subs)-1], true
+	}
+
+	inner = &syntax.Regexp{Op: syntax.OpConcat, Flags: re.Flags, Sub: subs}
+	return inner, begin, end
+}
+
+// reFromSyntax converts re to a regular expression of sort typ, the
+// sort of regular expressions over strings.
+func (c *Context) reFromSyntax(re *syntax.Regexp, typ *Sort) (*AST, error) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return c.ReEmpty(typ), nil
+
+	case syntax.OpEmptyMatch:
+		return c.Str("").ToRe(), nil
+
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase == 0 {
+			// No string holds characters above maxChar.
+			for _, r := range re.Rune {
+				if r > maxChar {
+					return c.ReEmpty(typ), nil
+				}
+			}
+			return c.runesStr(re.Rune).ToRe(), nil
+		}
+		chars := make([]*AST, len(re.Rune))
+		for i, r := range re.Rune {
+			chars[i] = c.reFromClass(foldCase(r), typ)
+		}
+		return c.reConcat(chars), nil
+
+	case syntax.OpCharClass:
+		return c.reFromClass(re.Rune, typ), nil
+
+	case syntax.OpAnyCharNotNL:
+		return c.reFromClass([]rune{0, '\n' - 1, '\n' + 1, maxChar}, typ), nil
+
+	case syntax.OpAnyChar:
+		return c.ReAllChar(typ), nil
+
+	case syntax.OpCapture:
+		return c.reFromSyntax(re.Sub[0], typ)
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		sub, err := c.reFromSyntax(re.Sub[0], typ)
+		if err != nil {
+			return nil, err
+		}
+		switch re.Op {
+		case syntax.OpStar:
+			return sub.ReStar(), nil
+		case syntax.OpPlus:
+			return sub.RePlus(), nil
+		default:
+			return sub.ReOption(), nil
+		}
+
+	case syntax.OpRepeat:
+		sub, err := c.reFromSyntax(re.Sub[0], typ)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case re.Max == 0:
+			return c.Str("").ToRe(), nil
+		case re.Max < 0:
+			// A maximum of 0 means no maximum to ReLoop.
+			return sub.ReLoop(uint(re.Min), 0), nil
+		default:
+			return sub.ReLoop(uint(re.Min), uint(re.Max)), nil
+		}
+
+	case syntax.OpConcat, syntax.OpAlternate:
+		subs := make([]*AST, len(re.Sub))
+		for i, sub := range re.Sub {
+			var err error
+			if subs[i], err = c.reFromSyntax(sub, typ); err != nil {
+				return nil, err
+			}
+		}
+		if re.Op == syntax.OpConcat {
+			return c.reConcat(subs), nil
+		}
+		if len(subs) == 0 {
+			return c.ReEmpty(typ), nil
+		}
+		return subs[0].ReUnion(subs[1:]...), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported regular expression %s", re)
+	}
+}
+
+// reConcat concatenates regular expressions over strings, any number of
+// them.
+func (c *Context) reConcat(res []*AST) *AST {
+	if len(res) == 0 {
+		return c.Str("").ToRe()
+	}
+	return res[0].ReConcat(res[1:]...)
+}
+
+// reFromClass converts the ranges of a character class, as lo-hi pairs,
+// to a regular expression.
+func (c *Context) reFromClass(ranges []rune, typ *Sort) *AST {
+	var res []*AST
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo > maxChar {
+			continue
+		}
+		if hi > maxChar {
+			hi = maxChar
+		}
+		res = append(res, c.ReRange(lo, hi))
+	}
+
+	if len(res) == 0 {
+		return c.ReEmpty(typ)
+	}
+	return res[0].ReUnion(res[1:]...)
+}
+
+// foldCase returns the character class of the characters equal to r under
+// simple case folding.
+func foldCase(r rune) []rune {
+	ranges := []rune{r, r}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		ranges = append(ranges, f, f)
+	}
+	return ranges
+}
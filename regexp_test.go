@@ -0,0 +1,99 @@
+package z3
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestReFromRegexp(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	inputs := []string{
+		"", "a", "abc", "ABC", "aBc", "x.example.com", "evil.com/x.example.com",
+		"a\nb", "123", "12a", "é", "Éé", "aaaa", "ab_9", " ",
+	}
+	exprs := []string{
+		`abc`, `^abc$`, `^a`, `c$`, `^$`, `(?i)abc`, `(?i)é`,
+		`^[a-z]+\.example\.com$`, `[a-z]+.example.com`,
+		`a.b`, `(?s)a.b`, `^\d+$`, `^\w*$`, `[^a-z]`, `\s`,
+		`^a{2}$`, `^a{2,3}$`, `^a{2,}$`, `^(?:ab|c)*$`, `^a*?$`, `^(a|b)+c?$`,
+		`^[\x{10000}-\x{10FFFF}]$`, `\A\d\z`, `^`, `x{0}`, `^\x{30000}$`, `a|\x{30000}`,
+	}
+
+	for _, expr := range exprs {
+		goRe := regexp.MustCompile(expr)
+		re, err := ctx.ReFromRegexp(goRe)
+		if err != nil {
+			t.Fatalf("%s: err: %s", expr, err)
+		}
+		for _, input := range inputs {
+			want := goRe.MatchString(input)
+			if v := ctx.Str(input).InRe(re).Simplify(); v.IsTrue() != want || v.IsFalse() == want {
+				t.Fatalf("%s on %q: bad: %s", expr, input, v)
+			}
+		}
+	}
+
+	for _, expr := range []string{`\bab`, `(?m)^a$`, `a^b`} {
+		if _, err := ctx.ReFromRegexp(regexp.MustCompile(expr)); err == nil {
+			t.Fatalf("%s: should fail", expr)
+		}
+	}
+}
+
+func TestReFromRegexpForbidden(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	// Check whether host patterns can match a string with a slash
+	reTyp := ctx.ReSort(ctx.StringSort())
+	forbidden := ctx.ReFull(reTyp).ReConcat(ctx.Str("/").ToRe(), ctx.ReFull(reTyp))
+	x := ctx.Const(ctx.Symbol("x"), ctx.StringSort())
+
+	anchored, err := ctx.ReFromRegexp(regexp.MustCompile(`^[a-z]+\.example\.com$`))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	s := ctx.MkSolver()
+	defer s.Close()
+	s.Assert(x.InRe(anchored.ReIntersect(forbidden)))
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %s", result)
+	}
+
+	goRe := regexp.MustCompile(`[a-z]\.com$`)
+	unanchored, err := ctx.ReFromRegexp(goRe)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	s.Reset()
+	s.Assert(x.InRe(unanchored))
+	s.Assert(x.InRe(forbidden))
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %s", result)
+	}
+	m := s.Model()
+	defer m.Close()
+	if v, err := m.Eval(x).StringValue(); err != nil || !goRe.MatchString(v) {
+		t.Fatalf("bad: %q %v", v, err)
+	}
+
+	// Parse trees match in full
+	tree, err := syntax.Parse(`a+`, syntax.Perl)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	re, err := ctx.ReFromSyntax(tree)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !ctx.Str("ba").InRe(re).Simplify().IsFalse() {
+		t.Fatal("should not match")
+	}
+}
@@ -0,0 +1,310 @@
+package z3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// #include "go-z3.h"
+import "C"
+
+// Session runs SMT-LIB2 commands through the command interpreter of a
+// context, like Context.EvalSMTLIB2, and parses the responses of the
+// common commands into Go values.
+//
+// Assert and GetValue accept ASTs built in Go: the sorts and functions
+// they use are declared to the interpreter first, unless the session
+// already declared them, including through Eval. Z3 has one interpreter
+// per context, so declarations made through other sessions or
+// EvalSMTLIB2 are not known to the session.
+type Session struct {
+	ctx *Context
+
+	// scopes holds the names declared at each push level, as "sort " or
+	// "fun " followed by the name. The first scope is the base level.
+	scopes []map[string]bool
+}
+
+// Definition is a definition of a model printed by get-model: the
+// interpretation of a constant, or of a function when it has Params.
+type Definition struct {
+	Name string
+
+	// Params are the parameters of a function, as (name sort) lists.
+	Params []SExpr
+
+	Sort  SExpr
+	Value SExpr
+}
+
+// MkSession creates a session on the command interpreter of the context.
+func (c *Context) MkSession() *Session {
+	return &Session{
+		ctx:    c,
+		scopes: []map[string]bool{{}},
+	}
+}
+
+// Eval runs SMT-LIB2 commands like Context.EvalSMTLIB2 does, keeping track
+// of the declarations they make and of push and pop. Like Z3, it goes on
+// with the next command when one fails, and returns the first error.
+func (s *Session) Eval(commands string) (string, error) {
+	// Commands that cannot be parsed fail in Z3 as well, so they need no
+	// tracking.
+	cmds, err := ParseSExprs(commands)
+	if err != nil {
+		return s.ctx.EvalSMTLIB2(commands)
+	}
+
+	// Z3 skips a failing command and runs the rest, so the commands run
+	// one at a time to track only those that succeed.
+	var output strings.Builder
+	var firstErr error
+	for _, cmd := range cmds {
+		out, err := s.ctx.EvalSMTLIB2(cmd.String())
+		output.WriteString(out)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		s.track(cmd)
+	}
+	return output.String(), firstErr
+}
+
+// Push creates a backtracking point, like Solver.Push does.
+func (s *Session) Push() error {
+	_, err := s.Eval("(push)")
+	return err
+}
+
+// Pop removes the assertions and declarations made since the matching
+// Push, like Solver.Pop does.
+func (s *Session) Pop() error {
+	_, err := s.Eval("(pop)")
+	return err
+}
+
+// Assert asserts a formula built in Go.
+func (s *Session) Assert(a *AST) error {
+	if err := s.declare([]*AST{a}); err != nil {
+		return err
+	}
+	_, err := s.Eval("(assert " + a.String() + ")")
+	return err
+}
+
+// CheckSat checks the satisfiability of the assertions, like Solver.Check
+// does.
+func (s *Session) CheckSat() (LBool, error) {
+	output, err := s.Eval("(check-sat)")
+	if err != nil {
+		return LBool(Undef), err
+	}
+
+	switch strings.TrimSpace(output) {
+	case "sat":
+		return True, nil
+	case "unsat":
+		return False, nil
+	case "unknown":
+		return LBool(Undef), nil
+	default:
+		return LBool(Undef), fmt.Errorf("z3: unexpected check-sat response: %q", output)
+	}
+}
+
+// GetModel returns the definitions of the model of the last check, which
+// must have been satisfiable, by their names.
+func (s *Session) GetModel() (map[string]Definition, error) {
+	output, err := s.Eval("(get-model)")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := parseResponse(output)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]Definition)
+	for _, def := range resp.List {
+		// Only define-fun is expected, but older versions start with
+		// the model atom.
+		if def.head() != "define-fun" || len(def.List) != 5 {
+			continue
+		}
+		result[def.List[1].Atom] = Definition{
+			Name:   def.List[1].Atom,
+			Params: def.List[2].List,
+			Sort:   def.List[3],
+			Value:  def.List[4],
+		}
+	}
+	return result, nil
+}
+
+// GetValue returns the values of terms built in Go in the model of the
+// last check, which must have been satisfiable, in the same order.
+func (s *Session) GetValue(terms ...*AST) ([]SExpr, error) {
+	if err := s.declare(terms); err != nil {
+		return nil, err
+	}
+
+	strs := make([]string, len(terms))
+	for i, t := range terms {
+		strs[i] = t.String()
+	}
+	output, err := s.Eval("(get-value (" + strings.Join(strs, " ") + "))")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := parseResponse(output)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.List) != len(terms) {
+		return nil, fmt.Errorf("z3: unexpected get-value response: %q", output)
+	}
+
+	result := make([]SExpr, len(terms))
+	for i, pair := range resp.List {
+		if len(pair.List) != 2 {
+			return nil, fmt.Errorf("z3: unexpected get-value response: %q", output)
+		}
+		result[i] = pair.List[1]
+	}
+	return result, nil
+}
+
+// parseResponse parses a response that is a single list.
+func parseResponse(output string) (SExpr, error) {
+	exprs, err := ParseSExprs(output)
+	if err != nil {
+		return SExpr{}, err
+	}
+	if len(exprs) != 1 || exprs[0].IsAtom() {
+		return SExpr{}, fmt.Errorf("z3: unexpected response: %q", output)
+	}
+	return exprs[0], nil
+}
+
+// declare declares the sorts and functions used by terms that the session
+// did not declare yet.
+func (s *Session) declare(terms []*AST) error {
+	// Z3 prints the declarations of a benchmark, which must be formulas.
+	eqs := make([]*AST, len(terms))
+	for i, t := range terms {
+		eqs[i] = t.Eq(t)
+	}
+	script := C.GoString(benchmarkToSMTLIB2(s.ctx.rawCtx, "", "", "unknown", eqs, nil))
+	cmds, err := ParseSExprs(script)
+	if err != nil {
+		return err
+	}
+
+	var decls []string
+	for _, cmd := range cmds {
+		if names := declaredNames(cmd); len(names) > 0 && !s.declared(names) {
+			decls = append(decls, cmd.String())
+		}
+	}
+	if len(decls) == 0 {
+		return nil
+	}
+	_, err = s.Eval(strings.Join(decls, "\n"))
+	return err
+}
+
+// declared returns true if all names were declared by the session.
+func (s *Session) declared(names []string) bool {
+	for _, name := range names {
+		found := false
+		for _, scope := range s.scopes {
+			if scope[name] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// track records the declarations made by cmd, or the scopes it pushes or
+// pops.
+func (s *Session) track(cmd SExpr) {
+	switch cmd.head() {
+	case "push":
+		for i := 0; i < scopeCount(cmd); i++ {
+			s.scopes = append(s.scopes, map[string]bool{})
+		}
+
+	case "pop":
+		n := len(s.scopes) - scopeCount(cmd)
+		if n < 1 {
+			n = 1
+		}
+		s.scopes = s.scopes[:n]
+
+	case "reset":
+		s.scopes = []map[string]bool{{}}
+
+	default:
+		for _, name := range declaredNames(cmd) {
+			s.scopes[len(s.scopes)-1][name] = true
+		}
+	}
+}
+
+// scopeCount returns the number of levels of a push or pop command.
+func scopeCount(cmd SExpr) int {
+	if len(cmd.List) < 2 {
+		return 1
+	}
+	n, err := strconv.Atoi(cmd.List[1].Atom)
+	if err != nil {
+		return 1
+	}
+	return n
+}
+
+// declaredNames returns the names a command declares, as "sort " or "fun "
+// followed by the name.
+func declaredNames(cmd SExpr) []string {
+	if len(cmd.List) < 2 {
+		return nil
+	}
+
+	name := cmd.List[1].Atom
+	switch cmd.head() {
+	case "declare-const", "declare-fun", "define-const", "define-fun", "define-fun-rec":
+		return []string{"fun " + name}
+
+	case "declare-sort", "define-sort", "declare-datatype":
+		return []string{"sort " + name}
+
+	case "declare-datatypes":
+		// Either (declare-datatypes ((name arity)*) (ctors*)) or, in the
+		// older syntax, (declare-datatypes (params*) ((name ctors*)*)).
+		var result []string
+		for _, sorts := range cmd.List[1:] {
+			for _, sort := range sorts.List {
+				if len(sort.List) > 0 {
+					result = append(result, "sort "+sort.List[0].Atom)
+				}
+			}
+			if len(result) > 0 {
+				return result
+			}
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package z3
+
+import (
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	s := ctx.MkSession()
+	if _, err := s.Eval("(declare-const x Int)"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Go-built terms can use the constants declared by commands, and
+	// the constants they use are declared as needed
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	y := ctx.Const(ctx.Symbol("y"), intTyp)
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{intTyp}, intTyp)
+	if err := s.Assert(x.Gt(y)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.Assert(f.Apply(y).Eq(ctx.Int(3, intTyp))); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.Assert(y.Eq(ctx.Int(4, intTyp))); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if result, err := s.CheckSat(); err != nil || result != True {
+		t.Fatalf("bad: %v %v", result, err)
+	}
+
+	values, err := s.GetValue(y, f.Apply(y), x.Gt(y))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(values) != 3 || values[0].Atom != "4" || values[1].Atom != "3" || values[2].Atom != "true" {
+		t.Fatalf("bad: %v", values)
+	}
+
+	model, err := s.GetModel()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d := model["y"]; d.Value.Atom != "4" || d.Sort.Atom != "Int" || len(d.Params) != 0 {
+		t.Fatalf("bad: %#v", d)
+	}
+	if d := model["f"]; len(d.Params) != 1 || d.Params[0].List[1].Atom != "Int" {
+		t.Fatalf("bad: %#v", d)
+	}
+
+	// Declarations made after Push are removed by Pop and declared again
+	// when needed
+	if err := s.Push(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	z := ctx.Const(ctx.Symbol("z"), intTyp)
+	if err := s.Assert(z.Lt(y)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.Pop(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.Assert(z.Gt(y)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if result, err := s.CheckSat(); err != nil || result != True {
+		t.Fatalf("bad: %v %v", result, err)
+	}
+}
+
+func TestSessionDatatype(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	pair := ctx.TupleSort("Pair",
+		DatatypeField{Name: "first", Sort: ctx.IntSort()},
+		DatatypeField{Name: "second", Sort: ctx.BoolSort()})
+	p := ctx.Const(ctx.Symbol("p"), pair.Sort())
+	first := pair.Constructors()[0].Accessors()[0]
+
+	s := ctx.MkSession()
+	if err := s.Assert(first.Apply(p).Eq(ctx.Int(7, ctx.IntSort()))); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if result, err := s.CheckSat(); err != nil || result != True {
+		t.Fatalf("bad: %v %v", result, err)
+	}
+
+	values, err := s.GetValue(p)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v := values[0].String(); v != "(Pair 7 false)" {
+		t.Fatalf("bad: %s", v)
+	}
+}
+
+func TestSessionEvalError(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	// Z3 rejects the declaration of w but still declares v, so only v
+	// counts as declared
+	s := ctx.MkSession()
+	if _, err := s.Eval("(declare-const w Foo) (declare-const v Int)"); err == nil {
+		t.Fatal("should fail")
+	}
+
+	v := ctx.Const(ctx.Symbol("v"), ctx.IntSort())
+	w := ctx.Const(ctx.Symbol("w"), ctx.IntSort())
+	if err := s.Assert(v.Gt(w)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if result, err := s.CheckSat(); err != nil || result != True {
+		t.Fatalf("bad: %v %v", result, err)
+	}
+}
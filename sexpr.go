@@ -0,0 +1,152 @@
+package z3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SExpr is an S-expression, the syntax of SMT-LIB2 commands and of the
+// responses to them: either an atom, such as sat, 42, |x y| or "abc", or
+// a list of S-expressions.
+type SExpr struct {
+	// Atom is the text of an atom, including the quotes of string literals
+	// and quoted symbols. It is empty for lists.
+	Atom string
+
+	// List holds the elements of a list. It is nil for atoms.
+	List []SExpr
+}
+
+// IsAtom returns true if the S-expression is an atom.
+func (e SExpr) IsAtom() bool {
+	return e.List == nil
+}
+
+// String returns the S-expression in SMT-LIB2 syntax.
+func (e SExpr) String() string {
+	if e.IsAtom() {
+		return e.Atom
+	}
+
+	elems := make([]string, len(e.List))
+	for i, elem := range e.List {
+		elems[i] = elem.String()
+	}
+	return "(" + strings.Join(elems, " ") + ")"
+}
+
+// head returns the first element of a list if it is an atom, such as the
+// name of a command, or "" otherwise.
+func (e SExpr) head() string {
+	if len(e.List) == 0 {
+		return ""
+	}
+	return e.List[0].Atom
+}
+
+// ParseSExprs parses the S-expressions in s, such as the output of
+// Context.EvalSMTLIB2. Comments are skipped.
+func ParseSExprs(s string) ([]SExpr, error) {
+	p := &sexprParser{s: s}
+	var result []SExpr
+	for {
+		p.skip()
+		if p.pos >= len(p.s) {
+			return result, nil
+		}
+
+		e, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+}
+
+type sexprParser struct {
+	s   string
+	pos int
+}
+
+// skip skips whitespace and comments.
+func (p *sexprParser) skip() {
+	for p.pos < len(p.s) {
+		switch p.s[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case ';':
+			for p.pos < len(p.s) && p.s[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *sexprParser) parse() (SExpr, error) {
+	p.skip()
+	if p.pos >= len(p.s) {
+		return SExpr{}, p.errorf("unexpected end of input")
+	}
+
+	start := p.pos
+	switch p.s[p.pos] {
+	case '(':
+		p.pos++
+		list := []SExpr{}
+		for {
+			p.skip()
+			if p.pos >= len(p.s) {
+				return SExpr{}, p.errorf("missing ')'")
+			}
+			if p.s[p.pos] == ')' {
+				p.pos++
+				return SExpr{List: list}, nil
+			}
+
+			e, err := p.parse()
+			if err != nil {
+				return SExpr{}, err
+			}
+			list = append(list, e)
+		}
+
+	case ')':
+		return SExpr{}, p.errorf("unexpected ')'")
+
+	case '"':
+		// A quote inside a string literal is written as two quotes.
+		for p.pos++; ; p.pos++ {
+			if p.pos >= len(p.s) {
+				return SExpr{}, p.errorf("unterminated string literal")
+			}
+			if p.s[p.pos] == '"' {
+				if p.pos+1 < len(p.s) && p.s[p.pos+1] == '"' {
+					p.pos++
+					continue
+				}
+				p.pos++
+				return SExpr{Atom: p.s[start:p.pos]}, nil
+			}
+		}
+
+	case '|':
+		end := strings.IndexByte(p.s[p.pos+1:], '|')
+		if end < 0 {
+			return SExpr{}, p.errorf("unterminated quoted symbol")
+		}
+		p.pos += end + 2
+		return SExpr{Atom: p.s[start:p.pos]}, nil
+
+	default:
+		for p.pos < len(p.s) && !strings.ContainsRune(" \t\r\n();\"|", rune(p.s[p.pos])) {
+			p.pos++
+		}
+		return SExpr{Atom: p.s[start:p.pos]}, nil
+	}
+}
+
+func (p *sexprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("z3: invalid S-expression at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,39 @@
+package z3
+
+import (
+	"testing"
+)
+
+func TestParseSExprs(t *testing.T) {
+	exprs, err := ParseSExprs(`sat ; comment
+		((x 2) (s "a""b") (|a b| (- 1)) ())`)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(exprs) != 2 || !exprs[0].IsAtom() || exprs[0].Atom != "sat" {
+		t.Fatalf("bad: %v", exprs)
+	}
+
+	values := exprs[1].List
+	if len(values) != 4 {
+		t.Fatalf("bad: %v", values)
+	}
+	if v := values[1].List[1].Atom; v != `"a""b"` {
+		t.Fatalf("bad: %s", v)
+	}
+	if v := values[2].List[0].Atom; v != "|a b|" {
+		t.Fatalf("bad: %s", v)
+	}
+	if values[3].IsAtom() || len(values[3].List) != 0 {
+		t.Fatalf("bad: %#v", values[3])
+	}
+	if v := exprs[1].String(); v != `((x 2) (s "a""b") (|a b| (- 1)) ())` {
+		t.Fatalf("bad: %s", v)
+	}
+
+	for _, s := range []string{"(x", ")", `"abc`, "|abc"} {
+		if _, err := ParseSExprs(s); err == nil {
+			t.Fatalf("should fail: %s", s)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package z3
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "go-z3.h"
+import "C"
+
+// ParseError is an error in SMT-LIB2 text, as reported by
+// ParseSMTLIB2String and ParseSMTLIB2File.
+type ParseError struct {
+	// File is the name of the parsed file, or empty if a string was
+	// parsed.
+	File string
+
+	// Line and Column are the position of the error, starting at 1. They
+	// are 0 if Z3 did not report a position.
+	Line   int
+	Column int
+
+	// Msg is the message of Z3 describing the error.
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	pos := e.File
+	if e.Line > 0 {
+		if pos != "" {
+			pos += ":"
+		}
+		pos += fmt.Sprintf("%d:%d", e.Line, e.Column)
+	}
+	if pos == "" {
+		return "z3: " + e.Msg
+	}
+	return "z3: " + pos + ": " + e.Msg
+}
+
+// Is reports whether target is ErrorCodeParserError, the code Z3 reports
+// parse errors with.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrorCodeParserError
+}
+
+// parseErrorRegexp matches the errors Z3 reports while parsing SMT-LIB2,
+// one per line.
+var parseErrorRegexp = regexp.MustCompile(`(?m)^\(error "line (\d+) column (\d+): (.*)"\)$`)
+
+// newParseError creates the error for the message of a parser error. Z3
+// keeps parsing after an error, so the message may report several; only
+// the first is kept.
+func newParseError(file, msg string) *ParseError {
+	m := parseErrorRegexp.FindStringSubmatch(msg)
+	if m == nil {
+		return &ParseError{File: file, Msg: msg}
+	}
+
+	line, _ := strconv.Atoi(m[1])
+	column, _ := strconv.Atoi(m[2])
+
+	// Z3 counts the columns of the first line from 1, but those of the
+	// following lines from 0.
+	if line > 1 {
+		column++
+	}
+	return &ParseError{
+		File:   file,
+		Line:   line,
+		Column: column,
+		Msg:    m[3],
+	}
+}
+
+// ParseSMTLIB2String parses SMT-LIB2 text and returns the formulas of its
+// assert commands. Other commands, such as check-sat, are ignored.
+//
+// The text may refer to the given sorts and declarations by their names,
+// without declaring them, so that the parsed formulas can be combined with
+// ASTs built in Go. Either may be nil.
+//
+// Parse errors are returned as a *ParseError and are not reported to the
+// error handler.
+//
+// Maps to: Z3_parse_smtlib2_string
+func (c *Context) ParseSMTLIB2String(s string, sorts []*Sort, decls []*FuncDecl) ([]*AST, error) {
+	return c.parseSMTLIB2("", s, sorts, decls)
+}
+
+// ParseSMTLIB2File is like ParseSMTLIB2String, but parses the contents of
+// the named file. Errors reading the file are returned as is.
+//
+// Maps to: Z3_parse_smtlib2_string
+func (c *Context) ParseSMTLIB2File(path string, sorts []*Sort, decls []*FuncDecl) ([]*AST, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return c.parseSMTLIB2(path, string(data), sorts, decls)
+}
+
+func (c *Context) parseSMTLIB2(file, s string, sorts []*Sort, decls []*FuncDecl) ([]*AST, error) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+
+	sortNames := make([]C.Z3_symbol, len(sorts))
+	rawSorts := make([]C.Z3_sort, len(sorts))
+	for i, sort := range sorts {
+		sortNames[i] = C.Z3_get_sort_name(c.rawCtx, sort.rawSort)
+		rawSorts[i] = sort.rawSort
+	}
+	declNames := make([]C.Z3_symbol, len(decls))
+	rawDecls := make([]C.Z3_func_decl, len(decls))
+	for i, decl := range decls {
+		declNames[i] = C.Z3_get_decl_name(c.rawCtx, decl.rawFuncDecl)
+		rawDecls[i] = decl.rawFuncDecl
+	}
+
+	var sortNamesPtr *C.Z3_symbol
+	var sortsPtr *C.Z3_sort
+	if len(sorts) > 0 {
+		sortNamesPtr, sortsPtr = &sortNames[0], &rawSorts[0]
+	}
+	var declNamesPtr *C.Z3_symbol
+	var declsPtr *C.Z3_func_decl
+	if len(decls) > 0 {
+		declNamesPtr, declsPtr = &declNames[0], &rawDecls[0]
+	}
+
+	var result []*AST
+	var err error
+	c.returnErrors(func() {
+		vec := C.Z3_parse_smtlib2_string(
+			c.rawCtx,
+			cs,
+			C.uint(len(sorts)),
+			sortNamesPtr,
+			sortsPtr,
+			C.uint(len(decls)),
+			declNamesPtr,
+			declsPtr)
+		code := C.Z3_get_error_code(c.rawCtx)
+		if code != C.Z3_OK {
+			if code == C.Z3_PARSER_ERROR {
+				err = newParseError(file, C.GoString(C.Z3_get_error_msg(c.rawCtx, code)))
+			} else {
+				err = newZ3Error(c.rawCtx, code)
+			}
+		}
+
+		// On errors Z3 still returns the formulas parsed so far, which
+		// are dropped.
+		if vec != nil {
+			result = astVectorToSlice(c.rawCtx, vec)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseTerm parses an SMT-LIB2 term after the given declarations, for the
+// functions that Z3 only offers in SMT-LIB2. It panics on parse errors, as
+// the terms are built by go-z3 itself.
+func (c *Context) parseTerm(decls, term string) *AST {
+	asts, err := c.ParseSMTLIB2String(
+		decls+"(assert (= "+term+" "+term+"))", nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	return asts[0].Arg(0)
+}
+
+// ToSMTLIB2 returns the assertions of the solver as a standalone SMT-LIB2
+// script, which declares the sorts and functions the assertions use and
+// ends with check-sat. It can be run with the z3 command line tool.
+//
+// Maps to: Z3_benchmark_to_smtlib_string
+func (s *Solver) ToSMTLIB2() string {
+	return C.GoString(s.toSMTLIB2())
+}
+
+// WriteSMTLIB2 is like ToSMTLIB2, but writes the script to w.
+//
+// Maps to: Z3_benchmark_to_smtlib_string
+func (s *Solver) WriteSMTLIB2(w io.Writer) error {
+	return writeCString(w, s.toSMTLIB2())
+}
+
+func (s *Solver) toSMTLIB2() *C.char {
+	return benchmarkToSMTLIB2(s.rawCtx, "", "", "unknown", s.Assertions(), nil)
+}
+
+// ToSMTLIB2 returns the formulas of the goal as a standalone SMT-LIB2
+// script, like Solver.ToSMTLIB2 does. The status of the script is sat or
+// unsat if the goal is already decided.
+//
+// Maps to: Z3_benchmark_to_smtlib_string
+func (g *Goal) ToSMTLIB2() string {
+	return C.GoString(g.toSMTLIB2())
+}
+
+// WriteSMTLIB2 is like ToSMTLIB2, but writes the script to w, like
+// Solver.WriteSMTLIB2 does.
+//
+// Maps to: Z3_benchmark_to_smtlib_string
+func (g *Goal) WriteSMTLIB2(w io.Writer) error {
+	return writeCString(w, g.toSMTLIB2())
+}
+
+func (g *Goal) toSMTLIB2() *C.char {
+	formulas := make([]*AST, g.GetGoalSize())
+	for i := range formulas {
+		formulas[i] = g.GetFormula(i)
+	}
+
+	status := "unknown"
+	if bool(C.Z3_goal_is_decided_sat(g.rawCtx, g.rawGoal)) {
+		status = "sat"
+	} else if bool(C.Z3_goal_is_decided_unsat(g.rawCtx, g.rawGoal)) {
+		status = "unsat"
+	}
+	checkError(g.rawCtx)
+	return benchmarkToSMTLIB2(g.rawCtx, "", "", status, formulas, nil)
+}
+
+// BenchmarkToSMTLIB2 returns a standalone SMT-LIB2 script that asserts the
+// assumptions and the formula, declares the sorts and functions they use
+// and ends with check-sat. The name is written as a comment, the logic
+// with set-logic unless it is empty, and the status, such as "sat",
+// "unsat" or "unknown", with set-info. The formula may be nil.
+//
+// Maps to: Z3_benchmark_to_smtlib_string
+func (c *Context) BenchmarkToSMTLIB2(name, logic, status string, assumptions []*AST, formula *AST) string {
+	return C.GoString(benchmarkToSMTLIB2(c.rawCtx, name, logic, status, assumptions, formula))
+}
+
+// WriteBenchmarkSMTLIB2 is like BenchmarkToSMTLIB2, but writes the script
+// to w, like Solver.WriteSMTLIB2 does.
+//
+// Maps to: Z3_benchmark_to_smtlib_string
+func (c *Context) WriteBenchmarkSMTLIB2(w io.Writer, name, logic, status string, assumptions []*AST, formula *AST) error {
+	return writeCString(w, benchmarkToSMTLIB2(c.rawCtx, name, logic, status, assumptions, formula))
+}
+
+// benchmarkToSMTLIB2 returns the script as a string owned by Z3, which is
+// valid until the next Z3 call.
+func benchmarkToSMTLIB2(rawCtx C.Z3_context, name, logic, status string, assumptions []*AST, formula *AST) *C.char {
+	if status == "" {
+		status = "unknown"
+	}
+	rawFormula := C.Z3_mk_true(rawCtx)
+	if formula != nil {
+		rawFormula = formula.rawAST
+	}
+
+	cname := C.CString(name)
+	clogic := C.CString(logic)
+	cstatus := C.CString(status)
+	cattrs := C.CString("")
+	defer C.free(unsafe.Pointer(cname))
+	defer C.free(unsafe.Pointer(clogic))
+	defer C.free(unsafe.Pointer(cstatus))
+	defer C.free(unsafe.Pointer(cattrs))
+
+	raws := make([]C.Z3_ast, len(assumptions))
+	for i, a := range assumptions {
+		raws[i] = a.rawAST
+	}
+	var ptr *C.Z3_ast
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+
+	return C.Z3_benchmark_to_smtlib_string(
+		rawCtx, cname, clogic, cstatus, cattrs, C.uint(len(raws)), ptr, rawFormula)
+}
+
+// writeCString writes a C string to w.
+func writeCString(w io.Writer, cs *C.char) error {
+	_, err := io.WriteString(w, C.GoString(cs))
+	return err
+}
+
+// EvalSMTLIB2 runs the SMT-LIB2 commands of script through the command
+// interpreter of the context and returns their output, such as sat or the
+// model printed by get-model. The interpreter keeps its state, such as the
+// declarations, the assertions and the last model, between calls. It is
+// separate from the state of the Solvers of the context. See Session for
+// running commands on ASTs built in Go.
+//
+// Z3 runs the commands that follow a failing command. The error of the
+// first failing command is returned as a *ParseError, along with the
+// output of all the commands, which includes the errors.
+//
+// Maps to: Z3_eval_smtlib2_string
+func (c *Context) EvalSMTLIB2(script string) (string, error) {
+	cs := C.CString(script)
+	defer C.free(unsafe.Pointer(cs))
+
+	var output string
+	var err error
+	c.returnErrors(func() {
+		output = C.GoString(C.Z3_eval_smtlib2_string(c.rawCtx, cs))
+		code := C.Z3_get_error_code(c.rawCtx)
+		if code == C.Z3_PARSER_ERROR {
+			err = newParseError("", output)
+		} else if code != C.Z3_OK {
+			err = newZ3Error(c.rawCtx, code)
+		}
+	})
+	return output, err
+}
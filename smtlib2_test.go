@@ -0,0 +1,228 @@
+package z3
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSMTLIB2String(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	// The text refers to a sort and a constant built in Go
+	elemTyp := ctx.UninterpretedSort(ctx.Symbol("Elem"))
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{elemTyp}, ctx.IntSort())
+	e := ctx.FuncDecl(ctx.Symbol("e"), nil, elemTyp)
+
+	asts, err := ctx.ParseSMTLIB2String(`
+		(declare-const y Elem)
+		(assert (> (f e) 1))
+		(assert (= y e))
+		(check-sat)
+	`, []*Sort{elemTyp}, []*FuncDecl{f, e})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(asts) != 2 {
+		t.Fatalf("bad: %v", asts)
+	}
+	if v := asts[0].String(); v != "(> (f e) 1)" {
+		t.Fatalf("bad: %s", v)
+	}
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	for _, a := range asts {
+		s.Assert(a)
+	}
+	s.Assert(f.Apply(e.Apply()).Lt(ctx.Int(0, ctx.IntSort())))
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+}
+
+func TestParseSMTLIB2StringError(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	// Parse errors are returned, not reported to the handler
+	ctx.SetErrorHandler(func(c *Context, code ErrorCode) {
+		t.Fatalf("handler called: %s", c.Error(code))
+	})
+
+	_, err := ctx.ParseSMTLIB2String("(declare-const x Int)\n(assert (> x y))", nil, nil)
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("bad: %#v", err)
+	}
+	if perr.Line != 2 || perr.Column != 14 || perr.Msg != "unknown constant y" {
+		t.Fatalf("bad: %#v", perr)
+	}
+	if !errors.Is(err, ErrorCodeParserError) {
+		t.Fatal("should be a parser error")
+	}
+	if v := err.Error(); v != "z3: 2:14: unknown constant y" {
+		t.Fatalf("bad: %s", v)
+	}
+}
+
+func TestParseSMTLIB2File(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	dir, err := ioutil.TempDir("", "z3")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test.smt2")
+	text := "(declare-const x Int)\n(assert (> x 1))\n(assert (< x 0)\n"
+	if err := ioutil.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, err = ctx.ParseSMTLIB2File(path, nil, nil)
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("bad: %#v", err)
+	}
+	if perr.File != path || perr.Line != 4 {
+		t.Fatalf("bad: %#v", perr)
+	}
+
+	if _, err := ctx.ParseSMTLIB2File(filepath.Join(dir, "missing.smt2"), nil, nil); !os.IsNotExist(err) {
+		t.Fatalf("bad: %#v", err)
+	}
+}
+
+func TestSolverToSMTLIB2(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	elemTyp := ctx.UninterpretedSort(ctx.Symbol("Elem"))
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	f := ctx.FuncDecl(ctx.Symbol("f"), []*Sort{elemTyp}, intTyp)
+	e := ctx.Const(ctx.Symbol("e"), elemTyp)
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	s.Assert(x.Gt(ctx.Int(1, intTyp)))
+	s.Assert(f.Apply(e).Eq(x))
+
+	script := s.ToSMTLIB2()
+	for _, v := range []string{"(declare-sort Elem 0)", "(declare-fun f (Elem) Int)", "(check-sat)"} {
+		if !strings.Contains(script, v) {
+			t.Fatalf("%q not in:\n%s", v, script)
+		}
+	}
+
+	// The script is standalone
+	other := MkContext(config)
+	defer other.Close()
+	asts, err := other.ParseSMTLIB2String(script, nil, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(asts) != 2 || asts[1].String() != "(= (f e) x)" {
+		t.Fatalf("bad: %v", asts)
+	}
+
+	var buf bytes.Buffer
+	if err := s.WriteSMTLIB2(&buf); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if buf.String() != script {
+		t.Fatalf("bad:\n%s", buf.String())
+	}
+}
+
+func TestGoalToSMTLIB2(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	g := ctx.MkGoal(true, false, false)
+	defer g.Close()
+	g.Assert(x.Gt(ctx.Int(1, ctx.IntSort())))
+
+	script := g.ToSMTLIB2()
+	for _, v := range []string{"(set-info :status unknown)", "(declare-fun x () Int)", "(> x 1)"} {
+		if !strings.Contains(script, v) {
+			t.Fatalf("%q not in:\n%s", v, script)
+		}
+	}
+}
+
+func TestBenchmarkToSMTLIB2(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	intTyp := ctx.IntSort()
+	x := ctx.Const(ctx.Symbol("x"), intTyp)
+	y := ctx.Const(ctx.Symbol("y"), intTyp)
+
+	script := ctx.BenchmarkToSMTLIB2(
+		"bug", "QF_LIA", "unsat", []*AST{x.Gt(y)}, y.Gt(x))
+	for _, v := range []string{"; bug", "(set-logic QF_LIA)", "(set-info :status unsat)", "(check-sat)"} {
+		if !strings.Contains(script, v) {
+			t.Fatalf("%q not in:\n%s", v, script)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := ctx.WriteBenchmarkSMTLIB2(&buf, "bug", "QF_LIA", "unsat", []*AST{x.Gt(y)}, y.Gt(x))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if buf.String() != script {
+		t.Fatalf("bad:\n%s", buf.String())
+	}
+}
+
+func TestEvalSMTLIB2(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	// The interpreter keeps its state between calls
+	if _, err := ctx.EvalSMTLIB2("(declare-const x Int)(assert (> x 1))"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	output, err := ctx.EvalSMTLIB2("(check-sat)(assert (< x 0))(check-sat)")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if output != "sat\nunsat\n" {
+		t.Fatalf("bad: %q", output)
+	}
+
+	// The commands after an error still run
+	output, err = ctx.EvalSMTLIB2("(assert (> y 1))\n(check-sat)")
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Msg != "unknown constant y" {
+		t.Fatalf("bad: %#v", err)
+	}
+	if !strings.HasSuffix(output, "unsat\n") {
+		t.Fatalf("bad: %q", output)
+	}
+}
@@ -1,5 +1,9 @@
 package z3
 
+import (
+	"context"
+)
+
 // #include "go-z3.h"
 import "C"
 
@@ -15,16 +19,36 @@ import "C"
 type Solver struct {
 	rawCtx    C.Z3_context
 	rawSolver C.Z3_solver
+
+	// remake creates an empty solver of the same kind and params holds
+	// the params set on this one, so that MinimizeCore can check on a
+	// solver with the same configuration.
+	remake func() *Solver
+	params []*Params
 }
 
-// MkSolver creates a new solver.
-func (c *Context) MkSolver() *Solver {
-	rawSolver := C.Z3_mk_solver(c.rawCtx)
-	C.Z3_solver_inc_ref(c.rawCtx, rawSolver)
-	return &Solver{
-		rawCtx:    c.rawCtx,
+// newSolver wraps a solver returned by Z3 and takes a reference to it,
+// which is released by Close or once the Solver is garbage collected.
+func newSolver(rawCtx C.Z3_context, rawSolver C.Z3_solver) *Solver {
+	checkError(rawCtx)
+	s := &Solver{
+		rawCtx:    rawCtx,
 		rawSolver: rawSolver,
 	}
+	if rawSolver != nil {
+		C.Z3_solver_inc_ref(rawCtx, rawSolver)
+		track(rawCtx, s, func() {
+			C.Z3_solver_dec_ref(rawCtx, rawSolver)
+		})
+	}
+	return s
+}
+
+// MkSolver creates a new solver.
+func (c *Context) MkSolver() *Solver {
+	s := newSolver(c.rawCtx, C.Z3_mk_solver(c.rawCtx))
+	s.remake = c.MkSolver
+	return s
 }
 
 // MkSolver creates a new solver for the provided logic
@@ -32,29 +56,25 @@ func (c *Context) MkSolver() *Solver {
 //
 // Maps to: Z3_mk_solver_for_logic
 func (c *Context) MkSolverForLogic(name string) *Solver {
-	rawSolver := C.Z3_mk_solver_for_logic(c.rawCtx, C.Z3_mk_string_symbol(c.rawCtx, C.CString(name)))
-	C.Z3_solver_inc_ref(c.rawCtx, rawSolver)
-	return &Solver{
-		rawCtx:    c.rawCtx,
-		rawSolver: rawSolver,
-	}
+	s := newSolver(c.rawCtx, C.Z3_mk_solver_for_logic(c.rawCtx, C.Z3_mk_string_symbol(c.rawCtx, C.CString(name))))
+	s.remake = func() *Solver { return c.MkSolverForLogic(name) }
+	return s
 }
 
 // Create a new solver that is implemented using the given tactic.
 //
 // Maps to: Z3_mk_solver_from_tactic
 func (c *Context) MkSolverFromTactic(t *Tactic) *Solver {
-	rawSolver := C.Z3_mk_solver_from_tactic(c.rawCtx, t.rawTactic)
-	C.Z3_solver_inc_ref(c.rawCtx, rawSolver)
-	return &Solver{
-		rawCtx:    c.rawCtx,
-		rawSolver: rawSolver,
-	}
+	s := newSolver(c.rawCtx, C.Z3_mk_solver_from_tactic(c.rawCtx, t.rawTactic))
+	// Hold a reference of our own, the caller may close t.
+	own := newTactic(c.rawCtx, t.rawTactic)
+	s.remake = func() *Solver { return c.MkSolverFromTactic(own) }
+	return s
 }
 
 // Close frees the memory associated with this.
 func (s *Solver) Close() error {
-	C.Z3_solver_dec_ref(s.rawCtx, s.rawSolver)
+	untrack(s.rawCtx, s)
 	return nil
 }
 
@@ -63,18 +83,23 @@ func (s *Solver) Close() error {
 // Maps to: Z3_solver_reset
 func (s *Solver) Reset()  {
 	C.Z3_solver_reset(s.rawCtx, s.rawSolver)
+	checkError(s.rawCtx)
 }
 
 // Convert a solver into a string.
 //
 // Maps to: Z3_solver_to_string
 func (s *Solver) String() string {
-	return C.GoString(C.Z3_solver_to_string(s.rawCtx, s.rawSolver))
+	result := C.GoString(C.Z3_solver_to_string(s.rawCtx, s.rawSolver))
+	checkError(s.rawCtx)
+	return result
 }
 
 // Maps to: Z3_solver_set_params
 func (s *Solver) SetParams(p *Params) {
 	C.Z3_solver_set_params(s.rawCtx, s.rawSolver, p.rawParams)
+	checkError(s.rawCtx)
+	s.params = append(s.params, newParams(s.rawCtx, p.rawParams))
 }
 
 
@@ -83,23 +108,174 @@ func (s *Solver) SetParams(p *Params) {
 // Maps to: Z3_solver_assert
 func (s *Solver) Assert(a *AST) {
 	C.Z3_solver_assert(s.rawCtx, s.rawSolver, a.rawAST)
+	checkError(s.rawCtx)
 }
 
 // Check checks if the currently set formula is consistent.
 //
 // Maps to: Z3_solver_check
 func (s *Solver) Check() LBool {
-	return LBool(C.Z3_solver_check(s.rawCtx, s.rawSolver))
+	result := LBool(C.Z3_solver_check(s.rawCtx, s.rawSolver))
+	checkError(s.rawCtx)
+	return result
+}
+
+// CheckContext is like Check, but interrupts the check when ctx is
+// canceled or its deadline passes. An interrupted check returns Undef and
+// a *CanceledError.
+//
+// Maps to: Z3_solver_check, Z3_interrupt
+func (s *Solver) CheckContext(ctx context.Context) (LBool, error) {
+	result := LBool(Undef)
+	err := interruptible(ctx, s.rawCtx, func() bool {
+		result = s.Check()
+		return result != Undef
+	})
+	return result, err
+}
+
+// CheckAssumptions checks if the currently set formula is consistent when
+// the given literals are assumed to be true. The assumptions only hold for
+// this check. When the result is False, UnsatCore returns the subset of
+// the assumptions that was needed to derive the conflict.
+//
+// Maps to: Z3_solver_check_assumptions
+func (s *Solver) CheckAssumptions(lits ...*AST) LBool {
+	raws := make([]C.Z3_ast, len(lits))
+	for i, lit := range lits {
+		raws[i] = lit.rawAST
+	}
+
+	var ptr *C.Z3_ast
+	if len(raws) > 0 {
+		ptr = &raws[0]
+	}
+	result := LBool(C.Z3_solver_check_assumptions(
+		s.rawCtx, s.rawSolver, C.uint(len(raws)), ptr))
+	checkError(s.rawCtx)
+	return result
+}
+
+// AssertAndTrack asserts a constraint onto the Solver and tracks it with
+// the Boolean constant label. When a later check is unsatisfiable, label
+// is part of UnsatCore if the constraint contributed to the conflict.
+//
+// Maps to: Z3_solver_assert_and_track
+func (s *Solver) AssertAndTrack(a, label *AST) {
+	C.Z3_solver_assert_and_track(s.rawCtx, s.rawSolver, a.rawAST, label.rawAST)
+	checkError(s.rawCtx)
+}
+
+// UnsatCore returns the assumptions and tracking labels used by the last
+// unsatisfiable check. The core is not guaranteed to be minimal, see
+// MinimizeCore.
+//
+// Maps to: Z3_solver_get_unsat_core
+func (s *Solver) UnsatCore() []*AST {
+	return astVectorToSlice(
+		s.rawCtx, C.Z3_solver_get_unsat_core(s.rawCtx, s.rawSolver))
+}
+
+// MinimizeCore shrinks an unsat core, such as the result of UnsatCore,
+// to a minimal one: removing any single literal of the result makes the
+// formula satisfiable (or unknown). Literals are tried one at a time and
+// dropped when the check without them is still unsatisfiable.
+//
+// Z3 implicitly assumes every AssertAndTrack label on each check, so the
+// checks run on a scratch solver holding the assertions of s, where the
+// labels are ordinary constants. The scratch solver is created for the
+// same logic or tactic as s and gets the params set on s. The state of s
+// is left untouched.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (s *Solver) MinimizeCore(core []*AST) []*AST {
+	scratch := s.remake()
+	defer scratch.Close()
+	for _, p := range s.params {
+		scratch.SetParams(p)
+	}
+	for _, a := range s.Assertions() {
+		scratch.Assert(a)
+	}
+
+	result := append([]*AST(nil), core...)
+	for i := 0; i < len(result); {
+		rest := make([]*AST, 0, len(result)-1)
+		rest = append(rest, result[:i]...)
+		rest = append(rest, result[i+1:]...)
+
+		if scratch.CheckAssumptions(rest...) == False {
+			result = rest
+		} else {
+			i++
+		}
+	}
+	return result
+}
+
+// Assertions returns the constraints asserted onto the Solver. Constraints
+// asserted with AssertAndTrack are returned as "label implies constraint".
+//
+// Maps to: Z3_solver_get_assertions
+func (s *Solver) Assertions() []*AST {
+	return astVectorToSlice(
+		s.rawCtx, C.Z3_solver_get_assertions(s.rawCtx, s.rawSolver))
+}
+
+// ReasonUnknown returns a brief explanation of why the last check
+// returned Undef, such as "timeout", "interrupted" or
+// "incomplete quantifiers".
+//
+// Maps to: Z3_solver_get_reason_unknown
+func (s *Solver) ReasonUnknown() string {
+	result := C.GoString(C.Z3_solver_get_reason_unknown(s.rawCtx, s.rawSolver))
+	checkError(s.rawCtx)
+	return result
 }
 
 // Model returns the last model from a Check.
 //
 // Maps to: Z3_solver_get_model
 func (s *Solver) Model() *Model {
-	m := &Model{
-		rawCtx:   s.rawCtx,
-		rawModel: C.Z3_solver_get_model(s.rawCtx, s.rawSolver),
-	}
-	C.Z3_model_inc_ref(m.rawCtx, m.rawModel)
-	return m
-}
\ No newline at end of file
+	return newModel(s.rawCtx, C.Z3_solver_get_model(s.rawCtx, s.rawSolver))
+}
+
+// Push creates a backtracking point. Assertions made after Push are
+// removed by the matching Pop.
+//
+// Maps to: Z3_solver_push
+func (s *Solver) Push() {
+	C.Z3_solver_push(s.rawCtx, s.rawSolver)
+	checkError(s.rawCtx)
+}
+
+// Pop removes the n most recent backtracking points and the assertions
+// made since then. n must not be larger than NumScopes.
+//
+// Maps to: Z3_solver_pop
+func (s *Solver) Pop(n uint) {
+	C.Z3_solver_pop(s.rawCtx, s.rawSolver, C.uint(n))
+	checkError(s.rawCtx)
+}
+
+// NumScopes returns the number of backtracking points.
+//
+// Maps to: Z3_solver_get_num_scopes
+func (s *Solver) NumScopes() uint {
+	result := uint(C.Z3_solver_get_num_scopes(s.rawCtx, s.rawSolver))
+	checkError(s.rawCtx)
+	return result
+}
+
+// Scoped runs f between a Push and its matching Pop, so that everything f
+// asserts is retracted afterwards. The Pop happens even if f panics; the
+// panic is then propagated. The error returned by f is returned as is.
+//
+// This doesn't map to any specific Z3 API. This is a higher-level function
+// provided by go-z3 to make the Z3 API easier to consume in Go.
+func (s *Solver) Scoped(f func() error) error {
+	s.Push()
+	defer s.Pop(1)
+	return f()
+}
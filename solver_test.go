@@ -62,4 +62,180 @@ func TestRealSolver(t *testing.T) {
 		t.Fatalf("bad: %v", result)
 	}
 
-}
\ No newline at end of file
+}
+
+func TestSolverPushPop(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	zero := ctx.Int(0, ctx.IntSort())
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	s.Assert(x.Gt(zero))
+
+	s.Push()
+	if s.NumScopes() != 1 {
+		t.Fatalf("bad: %d", s.NumScopes())
+	}
+	s.Assert(x.Lt(zero))
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+	s.Pop(1)
+
+	if s.NumScopes() != 0 {
+		t.Fatalf("bad: %d", s.NumScopes())
+	}
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+}
+
+func TestSolverScoped(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	zero := ctx.Int(0, ctx.IntSort())
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	s.Assert(x.Gt(zero))
+
+	err := s.Scoped(func() error {
+		s.Assert(x.Lt(zero))
+		if result := s.Check(); result != False {
+			t.Fatalf("bad: %v", result)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if s.NumScopes() != 0 {
+		t.Fatalf("bad: %d", s.NumScopes())
+	}
+
+	// The scope must also be popped when f panics
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("should panic")
+			}
+		}()
+		s.Scoped(func() error {
+			s.Assert(x.Lt(zero))
+			panic("boom")
+		})
+	}()
+	if s.NumScopes() != 0 {
+		t.Fatalf("bad: %d", s.NumScopes())
+	}
+	if result := s.Check(); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+}
+
+func TestSolverUnsatCore(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	a := ctx.Const(ctx.Symbol("a"), ctx.BoolSort())
+	b := ctx.Const(ctx.Symbol("b"), ctx.BoolSort())
+	c := ctx.Const(ctx.Symbol("c"), ctx.BoolSort())
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	s.Assert(a.Implies(x.Gt(ctx.Int(10, ctx.IntSort()))))
+	s.Assert(b.Implies(x.Lt(ctx.Int(5, ctx.IntSort()))))
+	s.Assert(c.Implies(x.Gt(ctx.Int(0, ctx.IntSort()))))
+
+	if result := s.CheckAssumptions(a, c); result != True {
+		t.Fatalf("bad: %v", result)
+	}
+	if result := s.CheckAssumptions(a, b, c); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+
+	core := s.MinimizeCore(s.UnsatCore())
+	if len(core) != 2 {
+		t.Fatalf("bad: %v", core)
+	}
+	for _, lit := range core {
+		if name := lit.String(); name != "a" && name != "b" {
+			t.Fatalf("bad: %v", core)
+		}
+	}
+}
+
+func TestSolverAssertAndTrack(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	ruleMin := ctx.Const(ctx.Symbol("rule_min"), ctx.BoolSort())
+	ruleMax := ctx.Const(ctx.Symbol("rule_max"), ctx.BoolSort())
+	rulePos := ctx.Const(ctx.Symbol("rule_pos"), ctx.BoolSort())
+
+	s := ctx.MkSolver()
+	defer s.Close()
+
+	s.AssertAndTrack(x.Ge(ctx.Int(100, ctx.IntSort())), ruleMin)
+	s.AssertAndTrack(x.Le(ctx.Int(50, ctx.IntSort())), ruleMax)
+	s.AssertAndTrack(x.Gt(ctx.Int(0, ctx.IntSort())), rulePos)
+
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+
+	core := s.MinimizeCore(s.UnsatCore())
+	if len(core) != 2 {
+		t.Fatalf("bad: %v", core)
+	}
+	for _, lit := range core {
+		if name := lit.String(); name != "rule_min" && name != "rule_max" {
+			t.Fatalf("bad: %v", core)
+		}
+	}
+}
+
+func TestSolverMinimizeCoreConfig(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	x := ctx.Const(ctx.Symbol("x"), ctx.IntSort())
+	a := ctx.Const(ctx.Symbol("a"), ctx.BoolSort())
+	b := ctx.Const(ctx.Symbol("b"), ctx.BoolSort())
+	c := ctx.Const(ctx.Symbol("c"), ctx.BoolSort())
+
+	// A solver that gives up on every check must not drop any literal,
+	// which only holds if the checks run with its tactic.
+	tactic := ctx.MkTactic("fail")
+	s := ctx.MkSolverFromTactic(tactic)
+	defer s.Close()
+	tactic.Close()
+
+	s.Assert(a.Implies(x.Ge(ctx.Int(100, ctx.IntSort()))))
+	s.Assert(b.Implies(x.Le(ctx.Int(50, ctx.IntSort()))))
+	s.Assert(c.Implies(ctx.False()))
+
+	if core := s.MinimizeCore([]*AST{a, b, c}); len(core) != 3 {
+		t.Fatalf("bad: %v", core)
+	}
+}
@@ -4,47 +4,205 @@ package z3
 import "C"
 
 // Sort represents a sort in Z3.
+//
+// Sort memory management is automatic, like it is for AST.
 type Sort struct {
 	rawCtx  C.Z3_context
 	rawSort C.Z3_sort
 }
 
+// newSort wraps a sort returned by Z3 and takes a reference to it, like
+// newAST does for ASTs.
+func newSort(rawCtx C.Z3_context, rawSort C.Z3_sort) *Sort {
+	checkError(rawCtx)
+	s := &Sort{
+		rawCtx:  rawCtx,
+		rawSort: rawSort,
+	}
+	if rawSort != nil {
+		raw := C.Z3_sort_to_ast(rawCtx, rawSort)
+		C.Z3_inc_ref(rawCtx, raw)
+		track(rawCtx, s, func() {
+			C.Z3_dec_ref(rawCtx, raw)
+		})
+	}
+	return s
+}
+
+// String returns a human-friendly string version of the sort.
+//
+// Maps to: Z3_sort_to_string
+func (s *Sort) String() string {
+	result := C.GoString(C.Z3_sort_to_string(s.rawCtx, s.rawSort))
+	checkError(s.rawCtx)
+	return result
+}
+
+// AST returns the sort as an AST, whose Kind is ASTKindSort.
+//
+// Maps to: Z3_sort_to_ast
+func (s *Sort) AST() *AST {
+	return newAST(s.rawCtx, C.Z3_sort_to_ast(s.rawCtx, s.rawSort))
+}
+
+// UninterpretedSort returns the uninterpreted type with the given name.
+// Two uninterpreted sorts are the same if they have the same name.
+//
+// Maps to: Z3_mk_uninterpreted_sort
+func (c *Context) UninterpretedSort(name *Symbol) *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_uninterpreted_sort(c.rawCtx, name.rawSymbol))
+}
+
 // BoolSort returns the boolean type.
 func (c *Context) BoolSort() *Sort {
-	return &Sort{
-		rawCtx:  c.rawCtx,
-		rawSort: C.Z3_mk_bool_sort(c.rawCtx),
-	}
+	return newSort(c.rawCtx, C.Z3_mk_bool_sort(c.rawCtx))
 }
 
 // IntSort returns the int type.
 func (c *Context) IntSort() *Sort {
-	return &Sort{
-		rawCtx:  c.rawCtx,
-		rawSort: C.Z3_mk_int_sort(c.rawCtx),
-	}
+	return newSort(c.rawCtx, C.Z3_mk_int_sort(c.rawCtx))
 }
 
 // RealSort returns the boolean type.
 func (c *Context) RealSort() *Sort {
-	return &Sort{
-		rawCtx:  c.rawCtx,
-		rawSort: C.Z3_mk_real_sort(c.rawCtx),
-	}
+	return newSort(c.rawCtx, C.Z3_mk_real_sort(c.rawCtx))
 }
 
 // StringSort returns the string type.
 func (c *Context) StringSort() *Sort {
-	return &Sort{
-		rawCtx:  c.rawCtx,
-		rawSort: C.Z3_mk_string_sort(c.rawCtx),
-	}
+	return newSort(c.rawCtx, C.Z3_mk_string_sort(c.rawCtx))
 }
 
 // SeqSort returns the seq type.
 func (c *Context) SeqSort(sort Sort) *Sort{
-	return &Sort{
-		rawCtx:  c.rawCtx,
-		rawSort: C.Z3_mk_seq_sort(c.rawCtx, sort.rawSort),
+	return newSort(c.rawCtx, C.Z3_mk_seq_sort(c.rawCtx, sort.rawSort))
+}
+
+// ReSort returns the sort of regular expressions over the given sequence
+// sort, such as StringSort.
+//
+// Maps to: Z3_mk_re_sort
+func (c *Context) ReSort(seq *Sort) *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_re_sort(c.rawCtx, seq.rawSort))
+}
+
+// BitVecSort returns a bit-vector type of the given width in bits.
+//
+// Maps to: Z3_mk_bv_sort
+func (c *Context) BitVecSort(width uint) *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_bv_sort(c.rawCtx, C.uint(width)))
+}
+
+// BVSize returns the width in bits of a bit-vector sort.
+//
+// Maps to: Z3_get_bv_sort_size
+func (s *Sort) BVSize() uint {
+	result := uint(C.Z3_get_bv_sort_size(s.rawCtx, s.rawSort))
+	checkError(s.rawCtx)
+	return result
+}
+
+// FPSort returns an IEEE-754 floating-point type with the given number of
+// exponent and significand bits. The significand width includes the
+// hidden bit, so FPSort(8, 24) is single precision.
+//
+// Maps to: Z3_mk_fpa_sort
+func (c *Context) FPSort(ebits, sbits uint) *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_fpa_sort(c.rawCtx, C.uint(ebits), C.uint(sbits)))
+}
+
+// FPSort16 returns the IEEE-754 half precision type.
+//
+// Maps to: Z3_mk_fpa_sort_16
+func (c *Context) FPSort16() *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_fpa_sort_16(c.rawCtx))
+}
+
+// FPSort32 returns the IEEE-754 single precision type.
+//
+// Maps to: Z3_mk_fpa_sort_32
+func (c *Context) FPSort32() *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_fpa_sort_32(c.rawCtx))
+}
+
+// FPSort64 returns the IEEE-754 double precision type.
+//
+// Maps to: Z3_mk_fpa_sort_64
+func (c *Context) FPSort64() *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_fpa_sort_64(c.rawCtx))
+}
+
+// FPSort128 returns the IEEE-754 quadruple precision type.
+//
+// Maps to: Z3_mk_fpa_sort_128
+func (c *Context) FPSort128() *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_fpa_sort_128(c.rawCtx))
+}
+
+// RoundingModeSort returns the floating-point rounding mode type.
+//
+// Maps to: Z3_mk_fpa_rounding_mode_sort
+func (c *Context) RoundingModeSort() *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_fpa_rounding_mode_sort(c.rawCtx))
+}
+
+// FPEBits returns the number of exponent bits of a floating-point sort.
+//
+// Maps to: Z3_fpa_get_ebits
+func (s *Sort) FPEBits() uint {
+	result := uint(C.Z3_fpa_get_ebits(s.rawCtx, s.rawSort))
+	checkError(s.rawCtx)
+	return result
+}
+
+// FPSBits returns the number of significand bits, including the hidden
+// bit, of a floating-point sort.
+//
+// Maps to: Z3_fpa_get_sbits
+func (s *Sort) FPSBits() uint {
+	result := uint(C.Z3_fpa_get_sbits(s.rawCtx, s.rawSort))
+	checkError(s.rawCtx)
+	return result
+}
+
+// ArraySort returns the type of arrays mapping domain to rng.
+//
+// Maps to: Z3_mk_array_sort
+func (c *Context) ArraySort(domain, rng *Sort) *Sort {
+	return newSort(c.rawCtx, C.Z3_mk_array_sort(c.rawCtx, domain.rawSort, rng.rawSort))
+}
+
+// ArraySortN returns the type of multi-dimensional arrays indexed by a
+// tuple of the given domains and mapping to rng.
+//
+// Maps to: Z3_mk_array_sort_n
+func (c *Context) ArraySortN(domains []*Sort, rng *Sort) *Sort {
+	raws := make([]C.Z3_sort, len(domains))
+	for i, d := range domains {
+		raws[i] = d.rawSort
+	}
+
+	var ptr *C.Z3_sort
+	if len(raws) > 0 {
+		ptr = &raws[0]
 	}
-}
\ No newline at end of file
+	return newSort(c.rawCtx, C.Z3_mk_array_sort_n(
+		c.rawCtx,
+		C.uint(len(raws)),
+		ptr,
+		rng.rawSort))
+}
+
+// ArrayDomain returns the (first) index type of an array sort.
+//
+// Maps to: Z3_get_array_sort_domain
+func (s *Sort) ArrayDomain() *Sort {
+	return newSort(s.rawCtx, C.Z3_get_array_sort_domain(s.rawCtx, s.rawSort))
+}
+
+// ArrayRange returns the element type of an array sort.
+//
+// Maps to: Z3_get_array_sort_range
+func (s *Sort) ArrayRange() *Sort {
+	return newSort(s.rawCtx, C.Z3_get_array_sort_range(s.rawCtx, s.rawSort))
+}
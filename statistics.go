@@ -0,0 +1,122 @@
+package z3
+
+import (
+	"time"
+)
+
+// #include "go-z3.h"
+import "C"
+
+// Statistics is a snapshot of the statistics Z3 collected while checking,
+// such as the number of conflicts or the memory in use. Every statistic
+// has either an unsigned integer value, found in Uints, or a floating
+// point value, found in Doubles.
+//
+// The keys are the names Z3 uses, such as "conflicts", "memory" or
+// "sat decisions". They depend on the solver Z3 picked for the problem.
+type Statistics struct {
+	Uints   map[string]uint
+	Doubles map[string]float64
+
+	str string
+}
+
+// Statistics returns the statistics of the solver, for the last check and
+// the solver overall.
+//
+// Maps to: Z3_solver_get_statistics
+func (s *Solver) Statistics() *Statistics {
+	return newStatistics(s.rawCtx, C.Z3_solver_get_statistics(s.rawCtx, s.rawSolver))
+}
+
+// Statistics returns the statistics of the optimize, for the last check.
+//
+// Maps to: Z3_optimize_get_statistics
+func (s *Optimize) Statistics() *Statistics {
+	return newStatistics(s.rawCtx, C.Z3_optimize_get_statistics(s.rawCtx, s.rawOptimize))
+}
+
+// newStatistics copies raw into a Statistics and releases raw.
+func newStatistics(rawCtx C.Z3_context, raw C.Z3_stats) *Statistics {
+	checkError(rawCtx)
+	stats := &Statistics{
+		Uints:   make(map[string]uint),
+		Doubles: make(map[string]float64),
+	}
+	if raw == nil {
+		return stats
+	}
+
+	C.Z3_stats_inc_ref(rawCtx, raw)
+	defer C.Z3_stats_dec_ref(rawCtx, raw)
+
+	stats.str = C.GoString(C.Z3_stats_to_string(rawCtx, raw))
+	n := C.Z3_stats_size(rawCtx, raw)
+	for i := C.uint(0); i < n; i++ {
+		key := C.GoString(C.Z3_stats_get_key(rawCtx, raw, i))
+		if bool(C.Z3_stats_is_uint(rawCtx, raw, i)) {
+			stats.Uints[key] = uint(C.Z3_stats_get_uint_value(rawCtx, raw, i))
+		} else {
+			stats.Doubles[key] = float64(C.Z3_stats_get_double_value(rawCtx, raw, i))
+		}
+	}
+	return stats
+}
+
+// String returns a human-friendly string version of the statistics.
+//
+// Maps to: Z3_stats_to_string
+func (s *Statistics) String() string {
+	return s.str
+}
+
+// Map returns all the statistics as floating point values, which is
+// convenient for exporting them as metrics.
+func (s *Statistics) Map() map[string]float64 {
+	result := make(map[string]float64, len(s.Uints)+len(s.Doubles))
+	for k, v := range s.Uints {
+		result[k] = float64(v)
+	}
+	for k, v := range s.Doubles {
+		result[k] = v
+	}
+	return result
+}
+
+// Conflicts returns the number of conflicts, of either the SMT core or
+// the SAT solver.
+func (s *Statistics) Conflicts() uint {
+	return s.firstUint("conflicts", "sat conflicts")
+}
+
+// Decisions returns the number of decisions, of either the SMT core or
+// the SAT solver.
+func (s *Statistics) Decisions() uint {
+	return s.firstUint("decisions", "sat decisions")
+}
+
+// Memory returns the memory in use by Z3, in megabytes.
+func (s *Statistics) Memory() float64 {
+	return s.Doubles["memory"]
+}
+
+// MaxMemory returns the peak memory in use by Z3, in megabytes.
+func (s *Statistics) MaxMemory() float64 {
+	return s.Doubles["max memory"]
+}
+
+// Time returns the time spent in the last check. It is zero when Z3 did
+// not report it.
+func (s *Statistics) Time() time.Duration {
+	return time.Duration(s.Doubles["time"] * float64(time.Second))
+}
+
+// firstUint returns the value of the first of keys that is present.
+func (s *Statistics) firstUint(keys ...string) uint {
+	for _, k := range keys {
+		if v, ok := s.Uints[k]; ok {
+			return v
+		}
+	}
+	return 0
+}
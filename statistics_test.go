@@ -0,0 +1,63 @@
+package z3
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSolverStatistics(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	assertPigeonhole(ctx, 6, s.Assert)
+
+	if result := s.Check(); result != False {
+		t.Fatalf("bad: %v", result)
+	}
+
+	stats := s.Statistics()
+	t.Logf("\nStatistics:\n%s", stats)
+	if stats.Conflicts() == 0 {
+		t.Fatalf("bad: %v", stats.Uints)
+	}
+	if stats.Decisions() == 0 {
+		t.Fatalf("bad: %v", stats.Uints)
+	}
+	if stats.Memory() <= 0 || stats.MaxMemory() < stats.Memory() {
+		t.Fatalf("bad: %v", stats.Doubles)
+	}
+
+	m := stats.Map()
+	if len(m) != len(stats.Uints)+len(stats.Doubles) {
+		t.Fatalf("bad: %v", m)
+	}
+	if m["memory"] != stats.Memory() {
+		t.Fatalf("bad: %v", m)
+	}
+}
+
+func TestSolverReasonUnknown(t *testing.T) {
+	config := MkConfig()
+	defer config.Close()
+	ctx := MkContext(config)
+	defer ctx.Close()
+
+	s := ctx.MkSolver()
+	defer s.Close()
+	assertPigeonhole(ctx, 12, s.Assert)
+
+	goCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if result, _ := s.CheckContext(goCtx); result != Undef {
+		t.Fatalf("bad: %v", result)
+	}
+	if reason := s.ReasonUnknown(); reason != "interrupted" {
+		t.Fatalf("bad: %q", reason)
+	}
+}
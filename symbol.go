@@ -22,9 +22,11 @@ func (c *Context) Symbol(name string) *Symbol {
 	ns := C.CString(name)
 	defer C.free(unsafe.Pointer(ns))
 
+	rawSymbol := C.Z3_mk_string_symbol(c.rawCtx, ns)
+	checkError(c.rawCtx)
 	return &Symbol{
 		rawCtx:    c.rawCtx,
-		rawSymbol: C.Z3_mk_string_symbol(c.rawCtx, ns),
+		rawSymbol: rawSymbol,
 	}
 }
 
@@ -32,9 +34,11 @@ func (c *Context) Symbol(name string) *Symbol {
 //
 // The memory associated with this symbol is freed when the context is freed.
 func (c *Context) SymbolInt(name int) *Symbol {
+	rawSymbol := C.Z3_mk_int_symbol(c.rawCtx, C.int(name))
+	checkError(c.rawCtx)
 	return &Symbol{
 		rawCtx:    c.rawCtx,
-		rawSymbol: C.Z3_mk_int_symbol(c.rawCtx, C.int(name)),
+		rawSymbol: rawSymbol,
 	}
 }
 
@@ -50,7 +54,9 @@ func (s *Symbol) String() string {
 	case C.Z3_STRING_SYMBOL:
 		// We don't need to free this value since it uses statically allocated
 		// space that is reused by Z3. The GoString call will copy the memory.
-		return C.GoString(C.Z3_get_symbol_string(s.rawCtx, s.rawSymbol))
+		result := C.GoString(C.Z3_get_symbol_string(s.rawCtx, s.rawSymbol))
+		checkError(s.rawCtx)
+		return result
 
 	default:
 		return "unknown symbol kind"